@@ -2,18 +2,28 @@ package config
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 )
 
 // Config configuration.
 type Config struct {
-	RedisURL              string   `env:"REDIS_URL" envDefault:"redis://localhost:6379"`
-	GitHubTokens          []string `env:"GITHUB_TOKENS"`
-	GitHubPageSize        int      `env:"GITHUB_PAGE_SIZE" envDefault:"100"`
-	GitHubMaxRateUsagePct int      `env:"GITHUB_MAX_RATE_LIMIT_USAGE" envDefault:"80"`
-	GitHubMaxSamplePages  int      `env:"GITHUB_MAX_SAMPLE_PAGES" envDefault:"15"`
-	Listen                string   `env:"LISTEN" envDefault:"127.0.0.1:3000"`
+	RedisURL              string        `env:"REDIS_URL" envDefault:"redis://localhost:6379"`
+	GitHubTokens          []string      `env:"GITHUB_TOKENS"`
+	GitHubPageSize        int           `env:"GITHUB_PAGE_SIZE" envDefault:"100"`
+	GitHubMaxRateUsagePct int           `env:"GITHUB_MAX_RATE_LIMIT_USAGE" envDefault:"80"`
+	GitHubMaxSamplePages  int           `env:"GITHUB_MAX_SAMPLE_PAGES" envDefault:"15"`
+	Listen                string        `env:"LISTEN" envDefault:"127.0.0.1:3000"`
+	ThemesFile            string        `env:"THEMES_FILE"`
+	FontFile              string        `env:"FONT_FILE"`
+	FontFamily            string        `env:"FONT_FAMILY"`
+	FontEmbed             bool          `env:"FONT_EMBED" envDefault:"false"`
+	Queue                 string        `env:"QUEUE" envDefault:"redis"`
+	CrawlWorkers          int           `env:"CRAWL_WORKERS" envDefault:"4"`
+	RefreshInterval       time.Duration `env:"REFRESH_INTERVAL" envDefault:"30m"`
+	RefreshTop            int           `env:"REFRESH_TOP" envDefault:"50"`
+	RefreshMaxRateUsage   int           `env:"REFRESH_MAX_RATE_LIMIT_USAGE" envDefault:"50"`
 }
 
 // Get the current Config.
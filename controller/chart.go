@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
@@ -11,31 +12,34 @@ import (
 	"github.com/caarlos0/httperr"
 	"github.com/caarlos0/starcharts/internal/cache"
 	"github.com/caarlos0/starcharts/internal/chart"
-	"github.com/caarlos0/starcharts/internal/chart/svg"
+	"github.com/caarlos0/starcharts/internal/crawler"
 	"github.com/caarlos0/starcharts/internal/github"
 )
 
-var stylesMap = map[string]string{
-	"light":    chart.LightStyles,
-	"dark":     chart.DarkStyles,
-	"adaptive": chart.AdaptiveStyles,
+// GetRepoChart returns the SVG chart for the given repository, or a
+// placeholder while its stargazers are being crawled.
+func GetRepoChart(pool *crawler.Pool, cache *cache.Redis) http.Handler {
+	return repoChart(pool, cache, false)
+}
+
+// GetRepoSparkline returns a compact SVG sparkline for the given repository.
+func GetRepoSparkline(pool *crawler.Pool, cache *cache.Redis) http.Handler {
+	return repoChart(pool, cache, true)
 }
 
-// GetRepoChart returns the SVG chart for the given repository.
-//
 // nolint: funlen
 // TODO: refactor.
-func GetRepoChart(gh *github.GitHub, cache *cache.Redis) http.Handler {
+func repoChart(pool *crawler.Pool, cache *cache.Redis, sparkline bool) http.Handler {
 	return httperr.NewF(func(w http.ResponseWriter, r *http.Request) error {
-		params, err := extractSvgChartParams(r)
+		params, err := extractSvgChartParams(r, sparkline)
 		if err != nil {
 			slog.Error("failed to extract params", "error", err)
-			return err
+			return httperr.Wrap(err, http.StatusBadRequest)
 		}
 
 		cacheKey := chartKey(params)
 		name := fmt.Sprintf("%s/%s", params.Owner, params.Repo)
-		log := slog.With("repo", name, "variant", params.Variant)
+		log := slog.With("repo", name, "theme", params.Theme.Name)
 
 		cachedChart := ""
 		if err = cache.Get(cacheKey, &cachedChart); err == nil {
@@ -45,70 +49,162 @@ func GetRepoChart(gh *github.GitHub, cache *cache.Redis) http.Handler {
 			return err
 		}
 
-		start := time.Now()
-		defer func() {
-			log.Debug("collect_stars", "duration", time.Since(start))
-		}()
-
-		repo, err := gh.RepoDetails(r.Context(), name)
+		crawled, ready, err := awaitCrawl(r.Context(), pool, name)
 		if err != nil {
-			return httperr.Wrap(err, http.StatusBadRequest)
+			log.Error("failed to get stars", "error", err)
+			return writeChartError(w, params, err)
 		}
 
-		stargazers, err := gh.Stargazers(r.Context(), repo)
-		if err != nil {
-			log.Error("failed to get stars", "error", err)
-			writeSvgHeaders(w)
-			_, err = w.Write([]byte(errSvg(err)))
-			return err
+		var comparedCrawl crawler.Result
+		if params.Compare != "" && ready {
+			comparedCrawl, ready, err = awaitCrawl(r.Context(), pool, params.Compare)
+			if err != nil {
+				log.Error("failed to get compared stars", "compare", params.Compare, "error", err)
+				return writeChartError(w, params, err)
+			}
 		}
 
-		series := chart.Series{
-			StrokeWidth: 2,
-			Color:       params.Line,
+		if !ready {
+			log.Info("crawl in progress, rendering placeholder")
+			return writePlaceholder(w, params)
 		}
-		for i, star := range stargazers {
-			series.XValues = append(series.XValues, star.StarredAt)
-			// If star.Count > 0, use the actual count from sampling mode
-			// Otherwise use index+1 (non-sampling mode, continuous data)
-			if star.Count > 0 {
-				series.YValues = append(series.YValues, float64(star.Count))
-			} else {
-				series.YValues = append(series.YValues, float64(i+1))
-			}
+
+		repo, stargazers := crawled.Repo, crawled.Stargazers
+
+		strokeWidth := params.Theme.StrokeWidth
+		if params.Sparkline {
+			strokeWidth = SPARKLINE_STROKE_WIDTH
 		}
-		if len(series.XValues) < 2 {
+
+		series := starSeries(name, stargazers)
+		series.StrokeWidth = strokeWidth
+		series.Color = params.Line
+		series.Smooth = params.Smooth
+		series.Animate = params.Animate
+		if params.Markers {
+			series.Samples = samples(stargazers)
+		}
+		if series.Len() < 2 {
 			log.Info("not enough results, adding some fake ones")
 			series.XValues = append(series.XValues, time.Now())
 			series.YValues = append(series.YValues, 1)
 		}
 
+		var secondary *chart.Series
+		compared, comparedStargazers := comparedCrawl.Repo, comparedCrawl.Stargazers
+		if params.Compare != "" {
+			s := starSeries(params.Compare, comparedStargazers)
+			s.StrokeWidth = strokeWidth
+			s.Smooth = params.Smooth
+			s.Animate = params.Animate
+			if params.Markers {
+				s.Samples = samples(comparedStargazers)
+			}
+			secondary = &s
+		}
+
+		xAxis := chart.XAxis{
+			Name:        "Time",
+			Color:       params.Axis,
+			StrokeWidth: params.Theme.AxisStrokeWidth,
+			Grid:        params.XGrid,
+		}
+		var shift time.Duration
+		if params.XAxis == X_AXIS_DAYS {
+			xAxis.Name = "Days since creation"
+			xAxis.Origin = createdAt(repo, series)
+			if secondary != nil && secondary.Len() > 0 {
+				// align both creation dates, so the series share the axis.
+				shift = xAxis.Origin.Sub(createdAt(compared, *secondary))
+			}
+		}
+
+		yAxis := chart.YAxis{
+			Name:        "Stargazers",
+			Color:       params.Axis,
+			StrokeWidth: params.Theme.AxisStrokeWidth,
+			Grid:        params.Grid,
+			Position:    axisPosition(params.YAxisPosition),
+		}
+		secondaryYAxis := chart.YAxis{
+			Name:        params.Compare,
+			Color:       params.Axis,
+			StrokeWidth: params.Theme.AxisStrokeWidth,
+			Position:    chart.AxisRight,
+		}
+		if yAxis.Position == chart.AxisRight {
+			secondaryYAxis.Position = chart.AxisLeft
+		}
+		if params.YAxis == Y_AXIS_PERCENT {
+			yAxis.Name = "Stargazers (%)"
+			yAxis.ValueFormatter = chart.PercentValueFormatter
+			series.Total = series.YValues[series.Len()-1]
+			if secondary != nil && secondary.Len() > 0 {
+				secondaryYAxis.Name = params.Compare + " (%)"
+				secondaryYAxis.ValueFormatter = chart.PercentValueFormatter
+				secondary.Total = secondary.YValues[secondary.Len()-1]
+			}
+		}
+
+		from, to, err := params.DateRange.Bounds(time.Now())
+		if err != nil {
+			return httperr.Wrap(err, http.StatusBadRequest)
+		}
+		if !from.IsZero() || !to.IsZero() {
+			series.Clip(from, to)
+			// the range ends before the first star, so there's nothing to plot.
+			if series.Len() < 2 || series.YValues[series.Len()-1] == 0 {
+				log.Info("no stargazers in the requested range")
+				return writeChartError(w, params, errEmptyRange)
+			}
+		}
+		if secondary != nil {
+			secondary.Clip(from, to)
+			if secondary.Len() < 2 {
+				secondary = nil
+			} else {
+				// shifted once clipped, as the range applies to the dates of
+				// both repositories.
+				secondary.Shift(shift)
+			}
+		}
+
 		chartStart := time.Now()
 		defer func() {
 			log.Debug("chart", "duration", time.Since(chartStart))
 		}()
+		var subtitle string
+		if params.Title != "" {
+			subtitle = fmt.Sprintf("%s stars", formatCount(repo.StargazersCount))
+		}
+
 		graph := &chart.Chart{
-			Width:      CHART_WIDTH,
-			Height:     CHART_HEIGHT,
-			Styles:     stylesMap[params.Variant],
-			Background: params.Background,
-			XAxis: chart.XAxis{
-				Name:        "Time",
-				Color:       params.Axis,
-				StrokeWidth: 2,
-			},
-			YAxis: chart.YAxis{
-				Name:        "Stargazers",
-				Color:       params.Axis,
-				StrokeWidth: 2,
-			},
-			Series: series,
+			ID:              chartID(params),
+			Title:           params.Title,
+			Subtitle:        subtitle,
+			Legend:          params.Legend || secondary != nil,
+			Width:           params.Width,
+			Height:          params.Height,
+			Theme:           &params.Theme,
+			Background:      params.Background,
+			PlotBackground:  params.Plot,
+			XAxis:           xAxis,
+			YAxis:           yAxis,
+			Series:          series,
+			SecondarySeries: secondary,
+			SecondaryYAxis:  secondaryYAxis,
+			Sparkline:       params.Sparkline,
+			Lang:            params.Lang,
+			Interactive:     params.Interactive,
+			Forecast:        params.Forecast,
 		}
 
 		writeSvgHeaders(w)
 
 		cacheBuffer := &strings.Builder{}
-		graph.Render(io.MultiWriter(w, cacheBuffer))
+		if err := graph.Render(io.MultiWriter(w, cacheBuffer)); err != nil {
+			return err
+		}
 		err = cache.Put(cacheKey, cacheBuffer.String())
 		if err != nil {
 			log.Error("failed to cache chart", "error", err)
@@ -118,17 +214,77 @@ func GetRepoChart(gh *github.GitHub, cache *cache.Redis) http.Handler {
 	})
 }
 
-func errSvg(err error) string {
-	return svg.SVG().
-		Attr("width", svg.Px(CHART_WIDTH)).
-		Attr("height", svg.Px(CHART_HEIGHT)).
-		ContentFunc(func(writer io.Writer) {
-			svg.Text().
-				Attr("fill", "red").
-				Attr("x", svg.Px(CHART_WIDTH/2)).
-				Attr("y", svg.Px(CHART_HEIGHT/2)).
-				Content(err.Error()).
-				Render(writer)
-		}).
-		String()
+// awaitCrawl returns the crawl of the given repository, enqueuing it and
+// waiting for it at most CRAWL_WAIT. If it isn't done by then, ready is false
+// and the crawl goes on in the background.
+func awaitCrawl(ctx context.Context, pool *crawler.Pool, name string) (result crawler.Result, ready bool, err error) {
+	if result, err := pool.Result(name); err == nil {
+		return result, true, nil
+	}
+
+	if _, err := pool.Enqueue(ctx, name); err != nil {
+		return result, false, err
+	}
+
+	status, err := pool.Wait(ctx, name, CRAWL_WAIT)
+	if err != nil {
+		return result, false, err
+	}
+	switch status.State {
+	case crawler.StateFailed:
+		return result, false, status.Err()
+	case crawler.StateDone:
+		result, err := pool.Result(name)
+		return result, err == nil, nil
+	default:
+		return result, false, nil
+	}
+}
+
+// starSeries builds the series of the cumulative stargazers count.
+func starSeries(name string, stargazers []github.Stargazer) chart.Series {
+	series := chart.Series{
+		Name:      name,
+		Tolerance: SERIES_TOLERANCE,
+	}
+	for i, star := range stargazers {
+		series.XValues = append(series.XValues, star.StarredAt)
+		// If star.Count > 0, use the actual count from sampling mode
+		// Otherwise use index+1 (non-sampling mode, continuous data)
+		if star.Count > 0 {
+			series.YValues = append(series.YValues, float64(star.Count))
+		} else {
+			series.YValues = append(series.YValues, float64(i+1))
+		}
+	}
+	return series
+}
+
+// samples returns when the stars fetched in sampling mode were starred, the
+// only actual points of an otherwise interpolated series.
+func samples(stargazers []github.Stargazer) []time.Time {
+	var times []time.Time
+	for _, star := range stargazers {
+		if star.Count > 0 {
+			times = append(times, star.StarredAt)
+		}
+	}
+	return times
+}
+
+func axisPosition(position string) chart.AxisPosition {
+	if position == Y_AXIS_LEFT {
+		return chart.AxisLeft
+	}
+	return chart.AxisRight
+}
+
+// createdAt returns when the repository was created, falling back to its
+// first star.
+func createdAt(repo github.Repository, series chart.Series) time.Time {
+	created, err := time.Parse(time.RFC3339, repo.CreatedAt)
+	if err != nil {
+		return series.XValues[0]
+	}
+	return created
 }
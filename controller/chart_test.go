@@ -0,0 +1,230 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/caarlos0/starcharts/internal/crawler"
+	"github.com/caarlos0/starcharts/internal/github"
+	"github.com/caarlos0/starcharts/internal/queue"
+	"github.com/go-redis/redis"
+	"github.com/gorilla/mux"
+	"github.com/matryer/is"
+)
+
+func testResult(name string) crawler.Result {
+	return crawler.Result{
+		Repo: github.Repository{
+			FullName:        name,
+			StargazersCount: 3,
+			CreatedAt:       "2020-01-01T00:00:00Z",
+		},
+		Stargazers: []github.Stargazer{
+			{StarredAt: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{StarredAt: time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{StarredAt: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+}
+
+// lateResult is a repository created a year after testResult's, starred
+// a year later too.
+func lateResult(name string) crawler.Result {
+	result := testResult(name)
+	result.Repo.CreatedAt = "2021-01-01T00:00:00Z"
+	for i := range result.Stargazers {
+		result.Stargazers[i].StarredAt = result.Stargazers[i].StarredAt.AddDate(1, 0, 0)
+	}
+	return result
+}
+
+func get(r http.Handler, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestGetRepoChart(t *testing.T) {
+	mr, _ := miniredis.Run()
+	t.Cleanup(mr.Close)
+	rc := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	cache := cache.New(rc)
+	t.Cleanup(func() { _ = cache.Close() })
+
+	release := make(chan struct{})
+	pool := crawler.NewPool(func(_ context.Context, name string) (crawler.Result, error) {
+		switch name {
+		case "not/found":
+			return crawler.Result{}, github.ErrorNotFound
+		case "private/test":
+			return crawler.Result{}, github.ErrPrivateRepository
+		case "rate/limited":
+			return crawler.Result{}, &github.RateLimitError{}
+		case "github/failure":
+			return crawler.Result{}, github.ErrGitHubAPI
+		case "slow/test":
+			<-release
+		case "late/test":
+			return lateResult(name), nil
+		case "zero/stars":
+			return crawler.Result{Repo: github.Repository{FullName: name}}, nil
+		}
+		return testResult(name), nil
+	}, cache, queue.NewMemory(10), 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	pool.Start(ctx)
+
+	r := mux.NewRouter()
+	r.Path("/{owner}/{repo}.svg").Handler(GetRepoChart(pool, cache))
+	r.Path("/{owner}/{repo}/sparkline.svg").Handler(GetRepoSparkline(pool, cache))
+
+	t.Run("placeholder", func(t *testing.T) {
+		is := is.New(t)
+		rec := get(r, "/slow/test.svg")
+		is.Equal(http.StatusAccepted, rec.Code)                                   // should accept while crawling
+		is.Equal("public, max-age=10", rec.Header().Get("cache-control"))         // should be cached briefly
+		is.Equal("10", rec.Header().Get("retry-after"))                           // should tell when to retry
+		is.Equal("image/svg+xml;charset=utf-8", rec.Header().Get("content-type")) // should be an svg
+		is.True(strings.Contains(rec.Body.String(), "Generating chart"))          // should render the placeholder
+
+		close(release)
+		status, err := pool.Wait(ctx, "slow/test", 5*time.Second)
+		is.NoErr(err)
+		is.Equal(crawler.StateDone, status.State) // should finish the crawl
+
+		rec = get(r, "/slow/test.svg")
+		is.Equal(http.StatusOK, rec.Code)                                 // should render the chart
+		is.True(!strings.Contains(rec.Body.String(), "Generating chart")) // should replace the placeholder
+		is.True(strings.Contains(rec.Body.String(), `class="series`))     // should draw the series
+	})
+
+	for _, tt := range []struct {
+		name   string
+		status int
+		title  string
+	}{
+		{"not/found", http.StatusNotFound, "Repository not found"},
+		{"private/test", http.StatusForbidden, "Private repository"},
+		{"rate/limited", http.StatusTooManyRequests, "Rate limited"},
+		{"github/failure", http.StatusBadGateway, "GitHub is unavailable"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			rec := get(r, "/"+tt.name+".svg")
+			is.Equal(tt.status, rec.Code)                           // should match the error
+			is.Equal("no-store", rec.Header().Get("cache-control")) // should not be cached
+			is.True(strings.Contains(rec.Body.String(), tt.title))  // should render the error card
+		})
+	}
+
+	t.Run("empty range", func(t *testing.T) {
+		is := is.New(t)
+		rec := get(r, "/test/test.svg?from=2019-01-01&to=2019-12-31")
+		is.Equal(http.StatusBadRequest, rec.Code)                                 // should be a bad request
+		is.Equal("image/svg+xml;charset=utf-8", rec.Header().Get("content-type")) // should be an svg
+		is.True(strings.Contains(rec.Body.String(), "No stargazers in range"))    // should render the error card
+	})
+
+	t.Run("zero stars", func(t *testing.T) {
+		is := is.New(t)
+		rec := get(r, "/zero/stars.svg")
+		is.Equal(http.StatusOK, rec.Code)                             // should render the chart
+		is.True(strings.Contains(rec.Body.String(), `class="series`)) // should draw the series
+	})
+
+	t.Run("compare in days", func(t *testing.T) {
+		is := is.New(t)
+		rec := get(r, "/test/test.svg?compare=late/test&xaxis=days&from=2021-01-15&to=2022-03-01")
+		is.Equal(http.StatusOK, rec.Code) // should render the chart
+		out := rec.Body.String()
+		is.True(strings.Contains(out, `class="series secondary`)) // should draw the compared series
+
+		// clipped to the range on its own dates, then aligned, the compared
+		// series starts a year before the chart one.
+		start := func(class string) string {
+			return regexp.MustCompile(`class="` + class + `" d="M (\d+) `).FindStringSubmatch(out)[1]
+		}
+		plot := regexp.MustCompile(`<rect x="(\d+)" [^>]*class="plot"`).FindStringSubmatch(out)[1]
+		is.Equal(plot, start("series secondary")) // should start the compared series at the plot start
+		is.True(start("series") != plot)          // should start the chart series later
+	})
+
+	t.Run("size", func(t *testing.T) {
+		for _, tt := range []struct {
+			query  string
+			status int
+			svg    string
+		}{
+			{"", http.StatusOK, `width="1024px" height="400px" viewBox="0 0 1024 400" preserveAspectRatio="xMidYMid meet"`},
+			{"?width=480&height=240", http.StatusOK, `width="480px" height="240px" viewBox="0 0 480 240" preserveAspectRatio="xMidYMid meet"`},
+			{"?width=319", http.StatusBadRequest, ""},
+			{"?width=4097", http.StatusBadRequest, ""},
+			{"?height=159", http.StatusBadRequest, ""},
+			{"?height=2049", http.StatusBadRequest, ""},
+		} {
+			t.Run(tt.query, func(t *testing.T) {
+				is := is.New(t)
+				rec := get(r, "/test/test.svg"+tt.query)
+				is.Equal(tt.status, rec.Code)                        // should bound the size
+				is.True(strings.Contains(rec.Body.String(), tt.svg)) // should scale the chart to its size
+			})
+		}
+	})
+
+	t.Run("sparkline", func(t *testing.T) {
+		is := is.New(t)
+		rec := get(r, "/test/test/sparkline.svg")
+		is.Equal(http.StatusOK, rec.Code) // should render the sparkline
+		out := rec.Body.String()
+		is.True(strings.Contains(out, `width="120px" height="30px" viewBox="0 0 120 30"`)) // should be small by default
+		is.True(strings.Contains(out, `class="series`))                                    // should draw the series
+		is.True(!strings.Contains(out, `class="y-axis"`))                                  // should omit the y axis
+		is.True(!strings.Contains(out, `class="x-axis"`))                                  // should omit the x axis
+		is.True(!strings.Contains(out, `class="plot"`))                                    // should omit the plot
+
+		rec = get(r, "/test/test/sparkline.svg?width=39")
+		is.Equal(http.StatusBadRequest, rec.Code) // should bound the sparkline size
+	})
+
+	t.Run("grid", func(t *testing.T) {
+		for _, tt := range []struct {
+			query        string
+			status       int
+			major, minor bool
+		}{
+			{"", http.StatusOK, false, false},
+			{"?grid=major", http.StatusOK, true, false},
+			{"?xgrid=minor", http.StatusOK, false, true},
+			{"?grid=all", http.StatusOK, true, true},
+			{"?grid=some", http.StatusBadRequest, false, false},
+		} {
+			t.Run(tt.query, func(t *testing.T) {
+				is := is.New(t)
+				rec := get(r, "/test/test.svg"+tt.query)
+				is.Equal(tt.status, rec.Code)                                                       // should validate the grid
+				is.Equal(tt.major, strings.Contains(rec.Body.String(), `<line class="grid-major"`)) // should toggle the major lines
+				is.Equal(tt.minor, strings.Contains(rec.Body.String(), `<line class="grid-minor"`)) // should toggle the minor lines
+			})
+		}
+	})
+
+	t.Run("interactive", func(t *testing.T) {
+		is := is.New(t)
+		rec := get(r, "/test/test.svg?interactive=true")
+		is.Equal(http.StatusOK, rec.Code)                                                  // should render the chart
+		is.True(strings.Contains(rec.Body.String(), `<title>2021-02-01: 2 stars</title>`)) // should render the tooltips
+
+		rec = get(r, "/test/test.svg?interactive=maybe")
+		is.Equal(http.StatusBadRequest, rec.Code) // should validate the param
+	})
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/chart"
+	"github.com/caarlos0/starcharts/internal/github"
+)
+
+// errEmptyRange happens when the requested date range has too few stargazers
+// to draw the chart.
+var errEmptyRange = errors.New("no stargazers in the requested range")
+
+// chartError is why a chart couldn't be drawn, rendered as a card in its
+// place with the matching status code.
+type chartError struct {
+	status int
+	card   chart.Card
+	// retryAfter is how long until the request may succeed, if known.
+	retryAfter time.Duration
+}
+
+func newChartError(err error, now time.Time) chartError {
+	var rateLimit *github.RateLimitError
+	switch {
+	case errors.As(err, &rateLimit):
+		ce := chartError{
+			status: http.StatusTooManyRequests,
+			card: chart.Card{
+				Title:   "Rate limited",
+				Message: "GitHub's API rate limit was reached.",
+				Hint:    "Please try again later.",
+			},
+		}
+		if !rateLimit.RetryAt.IsZero() {
+			ce.retryAfter = max(rateLimit.RetryAt.Sub(now), time.Second)
+			ce.card.Hint = fmt.Sprintf("Please try again in %s.", humanizeDuration(ce.retryAfter))
+		}
+		return ce
+	case errors.Is(err, github.ErrRateLimit):
+		return chartError{
+			status: http.StatusTooManyRequests,
+			card: chart.Card{
+				Title:   "Rate limited",
+				Message: "GitHub's API rate limit was reached.",
+				Hint:    "Please try again later.",
+			},
+		}
+	case errors.Is(err, errEmptyRange):
+		return chartError{
+			status: http.StatusBadRequest,
+			card: chart.Card{
+				Title:   "No stargazers in range",
+				Message: "The repository wasn't starred in the requested range.",
+				Hint:    "Try a wider range.",
+			},
+		}
+	case errors.Is(err, github.ErrorNotFound):
+		return chartError{
+			status: http.StatusNotFound,
+			card: chart.Card{
+				Title:   "Repository not found",
+				Message: "Check the owner and repository names.",
+			},
+		}
+	case errors.Is(err, github.ErrPrivateRepository):
+		return chartError{
+			status: http.StatusForbidden,
+			card: chart.Card{
+				Title:   "Private repository",
+				Message: "Only public repositories can be charted.",
+			},
+		}
+	default:
+		return chartError{
+			status: http.StatusBadGateway,
+			card: chart.Card{
+				Title:   "GitHub is unavailable",
+				Message: "Couldn't get the stargazers from GitHub.",
+				Hint:    "Please try again later.",
+			},
+		}
+	}
+}
+
+// humanizeDuration rounds d up to minutes or hours, e.g. "5 minutes".
+func humanizeDuration(d time.Duration) string {
+	minutes := int(math.Ceil(d.Minutes()))
+	switch {
+	case minutes <= 1:
+		return "a minute"
+	case minutes < 90:
+		return fmt.Sprintf("%d minutes", minutes)
+	default:
+		return fmt.Sprintf("%d hours", int(math.Round(d.Hours())))
+	}
+}
+
+// writeChartError renders err as a card matching the requested chart, so it
+// still fits where the chart is embedded.
+func writeChartError(w http.ResponseWriter, params *params, err error) error {
+	ce := newChartError(err, time.Now())
+
+	header := w.Header()
+	header.Add("content-type", "image/svg+xml;charset=utf-8")
+	header.Add("cache-control", "no-store")
+	if ce.retryAfter > 0 {
+		header.Add("retry-after", strconv.Itoa(int(math.Ceil(ce.retryAfter.Seconds()))))
+	}
+	w.WriteHeader(ce.status)
+
+	graph := cardChart(params)
+	graph.Error = &ce.card
+	return graph.Render(w)
+}
+
+// writePlaceholder renders a card telling the chart is being generated,
+// cached briefly so the chart replaces it once its crawl is done.
+func writePlaceholder(w http.ResponseWriter, params *params) error {
+	maxAge := strconv.Itoa(int(PLACEHOLDER_MAX_AGE.Seconds()))
+
+	header := w.Header()
+	header.Add("content-type", "image/svg+xml;charset=utf-8")
+	header.Add("cache-control", "public, max-age="+maxAge)
+	header.Add("retry-after", maxAge)
+	w.WriteHeader(http.StatusAccepted)
+
+	graph := cardChart(params)
+	graph.Placeholder = &chart.Card{
+		Title:   "Generating chart…",
+		Message: fmt.Sprintf("Fetching the stargazers of %s/%s.", params.Owner, params.Repo),
+		Hint:    "This may take a minute, reload to see the chart.",
+	}
+	return graph.Render(w)
+}
+
+// cardChart is a chart matching the requested one, to render a card in its
+// place.
+func cardChart(params *params) *chart.Chart {
+	return &chart.Chart{
+		ID:         chartID(params),
+		Width:      params.Width,
+		Height:     params.Height,
+		Theme:      &params.Theme,
+		Background: params.Background,
+		Sparkline:  params.Sparkline,
+		Lang:       params.Lang,
+	}
+}
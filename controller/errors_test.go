@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/github"
+	"github.com/matryer/is"
+)
+
+func TestNewChartError(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name       string
+		err        error
+		status     int
+		hint       string
+		retryAfter time.Duration
+	}{
+		{
+			name:   "rate limited",
+			err:    github.ErrRateLimit,
+			status: http.StatusTooManyRequests,
+			hint:   "Please try again later.",
+		},
+		{
+			name:   "rate limited without reset",
+			err:    &github.RateLimitError{},
+			status: http.StatusTooManyRequests,
+			hint:   "Please try again later.",
+		},
+		{
+			name:       "rate limited with reset",
+			err:        fmt.Errorf("wrapped: %w", &github.RateLimitError{RetryAt: now.Add(5 * time.Minute)}),
+			status:     http.StatusTooManyRequests,
+			hint:       "Please try again in 5 minutes.",
+			retryAfter: 5 * time.Minute,
+		},
+		{
+			name:       "rate limited with past reset",
+			err:        &github.RateLimitError{RetryAt: now.Add(-time.Minute)},
+			status:     http.StatusTooManyRequests,
+			hint:       "Please try again in a minute.",
+			retryAfter: time.Second,
+		},
+		{
+			name:   "not found",
+			err:    github.ErrorNotFound,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "empty range",
+			err:    errEmptyRange,
+			status: http.StatusBadRequest,
+			hint:   "Try a wider range.",
+		},
+		{
+			name:   "private",
+			err:    github.ErrPrivateRepository,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "other",
+			err:    errors.New("boom"),
+			status: http.StatusBadGateway,
+			hint:   "Please try again later.",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			ce := newChartError(tt.err, now)
+			is.Equal(tt.status, ce.status)         // should map the status code
+			is.Equal(tt.hint, ce.card.Hint)        // should hint when to retry
+			is.Equal(tt.retryAfter, ce.retryAfter) // should tell when to retry
+		})
+	}
+}
+
+func TestHumanizeDuration(t *testing.T) {
+	for d, expected := range map[time.Duration]string{
+		time.Second:                  "a minute",
+		time.Minute:                  "a minute",
+		61 * time.Second:             "2 minutes",
+		89 * time.Minute:             "89 minutes",
+		90 * time.Minute:             "2 hours",
+		5*time.Hour + 10*time.Minute: "5 hours",
+	} {
+		t.Run(d.String(), func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(expected, humanizeDuration(d))
+		})
+	}
+}
@@ -2,10 +2,15 @@ package controller
 
 import (
 	"fmt"
+	"hash/fnv"
 	"net/http"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/caarlos0/starcharts/internal/chart"
 	"github.com/gorilla/mux"
 )
 
@@ -17,6 +22,32 @@ const (
 
 var colorExpression = regexp.MustCompile("^#([a-fA-F0-9]{6}|[a-fA-F0-9]{3}|[a-fA-F0-9]{8})$")
 
+var repoExpression = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+
+var windowExpression = regexp.MustCompile(`^(\d+)([dwmy])$`)
+
+var windowUnits = map[string]time.Duration{
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+	"m": 30 * 24 * time.Hour,
+	"y": 365 * 24 * time.Hour,
+}
+
+// parseWindow parses a relative window such as 90d, 12w, 6m or 1y.
+func parseWindow(value string) (time.Duration, error) {
+	matches := windowExpression.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid window: %s", value)
+	}
+
+	count, err := strconv.Atoi(matches[1])
+	if err != nil || count == 0 {
+		return 0, fmt.Errorf("invalid window: %s", value)
+	}
+
+	return time.Duration(count) * windowUnits[matches[2]], nil
+}
+
 func extractColor(r *http.Request, name string) (string, error) {
 	color := r.URL.Query().Get(name)
 	if len(color) == 0 {
@@ -30,16 +61,269 @@ func extractColor(r *http.Request, name string) (string, error) {
 	return "", fmt.Errorf("invalid %s: %s", name, color)
 }
 
+func extractSize(r *http.Request, name string, defaultValue, minValue, maxValue int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+
+	size, err := strconv.Atoi(value)
+	if err != nil || size < minValue || size > maxValue {
+		return 0, fmt.Errorf("invalid %s: %s, must be between %d and %d", name, value, minValue, maxValue)
+	}
+
+	return size, nil
+}
+
+func extractBool(r *http.Request, name string) (bool, error) {
+	value := r.URL.Query().Get(name)
+	if len(value) == 0 {
+		return false, nil
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %s", name, value)
+	}
+
+	return result, nil
+}
+
+func extractTitle(r *http.Request, defaultValue string) (string, error) {
+	if !r.URL.Query().Has("title") {
+		return defaultValue, nil
+	}
+
+	title := r.URL.Query().Get("title")
+	if len([]rune(title)) > MAX_TITLE_LENGTH {
+		return "", fmt.Errorf("invalid title: must be at most %d characters", MAX_TITLE_LENGTH)
+	}
+
+	return title, nil
+}
+
+func extractLang(r *http.Request) (string, error) {
+	lang := r.URL.Query().Get("lang")
+	if len(lang) == 0 {
+		return chart.DefaultLang, nil
+	}
+
+	if chart.SupportedLang(lang) {
+		return lang, nil
+	}
+
+	return "", fmt.Errorf("invalid lang: %s", lang)
+}
+
+// parseDate parses a date such as 2021-03-17, or a full RFC3339 timestamp.
+func parseDate(name, value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid %s: %s, must be a date such as 2021-03-17", name, value)
+}
+
+func extractDateRange(r *http.Request) (dateRange, error) {
+	query := r.URL.Query()
+	result := dateRange{
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Window: query.Get("window"),
+	}
+
+	if result.From != "" && result.Window != "" {
+		return result, fmt.Errorf("invalid range: from and window can't be used together")
+	}
+
+	_, _, err := result.Bounds(time.Now())
+	return result, err
+}
+
+// dateRange is the requested date range, as given in the query, so
+// relative windows keep the same cache key over time.
+type dateRange struct {
+	From   string
+	To     string
+	Window string
+}
+
+// Bounds returns the absolute range bounds, relative to now for windows.
+// Zero bounds are unbounded.
+func (d dateRange) Bounds(now time.Time) (from, to time.Time, err error) {
+	if d.To != "" {
+		if to, err = parseDate("to", d.To); err != nil {
+			return
+		}
+	}
+
+	switch {
+	case d.From != "":
+		if from, err = parseDate("from", d.From); err != nil {
+			return
+		}
+	case d.Window != "":
+		var window time.Duration
+		if window, err = parseWindow(d.Window); err != nil {
+			return
+		}
+		end := to
+		if end.IsZero() {
+			end = now
+			to = now
+		}
+		from = end.Add(-window)
+	}
+
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		err = fmt.Errorf("invalid range: from must be before to")
+	}
+	return
+}
+
+func extractForecast(r *http.Request) (*chart.Forecast, error) {
+	var model chart.ForecastModel
+	switch value := r.URL.Query().Get("forecast"); value {
+	case "", "none":
+		return nil, nil
+	case "linear":
+		model = chart.LinearForecast
+	case "exponential":
+		model = chart.ExponentialForecast
+	default:
+		return nil, fmt.Errorf("invalid forecast: %s, must be one of none, linear or exponential", value)
+	}
+
+	window := FORECAST_WINDOW
+	if value := r.URL.Query().Get("forecast_window"); len(value) > 0 {
+		var err error
+		window, err = parseWindow(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &chart.Forecast{
+		Model:  model,
+		Window: window,
+	}, nil
+}
+
+// extractChoice extracts one of the given choices, defaulting to the first.
+func extractChoice(r *http.Request, name string, choices ...string) (string, error) {
+	value := r.URL.Query().Get(name)
+	if len(value) == 0 {
+		return choices[0], nil
+	}
+
+	if slices.Contains(choices, value) {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("invalid %s: %s, must be one of %s", name, value, strings.Join(choices, ", "))
+}
+
+// ParseRepo parses a repository given as owner/repo or as its github URL,
+// with or without its scheme, a .git suffix or a deeper path, e.g.
+// https://github.com/owner/repo/tree/main.
+func ParseRepo(value string) (string, error) {
+	name := strings.TrimSpace(value)
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.TrimPrefix(name, "http://")
+	name = strings.TrimPrefix(name, "www.")
+	name = strings.TrimPrefix(name, "github.com/")
+	if parts := strings.SplitN(name, "/", 3); len(parts) == 3 {
+		name = parts[0] + "/" + parts[1]
+	}
+	name = strings.TrimSuffix(name, ".git")
+
+	if !repoExpression.MatchString(name) {
+		return "", fmt.Errorf("invalid repository: %s, must be owner/repo", value)
+	}
+	return name, nil
+}
+
+// extractCompare extracts the owner/repo to plot against the chart
+// repository on a secondary axis.
+func extractCompare(r *http.Request) (string, error) {
+	value := r.URL.Query().Get("compare")
+	if len(value) == 0 {
+		return "", nil
+	}
+
+	name, err := ParseRepo(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid compare: %s, must be owner/repo", value)
+	}
+	return name, nil
+}
+
+// extractTheme extracts the theme from the registry. The variant parameter
+// is kept for the charts already embedded with it, and falls back to the
+// light theme when unknown.
+func extractTheme(r *http.Request) (chart.Theme, error) {
+	if name := r.URL.Query().Get("theme"); len(name) > 0 {
+		theme, ok := chart.Themes.Get(name)
+		if !ok {
+			return theme, fmt.Errorf("invalid theme: %s, must be one of %s", name, strings.Join(chart.Themes.Names(), ", "))
+		}
+		return theme, nil
+	}
+
+	if theme, ok := chart.Themes.Get(r.URL.Query().Get("variant")); ok {
+		return theme, nil
+	}
+	return chart.LightTheme, nil
+}
+
+func extractGrid(r *http.Request, name string) (chart.Grid, error) {
+	switch value := r.URL.Query().Get(name); value {
+	case "", "none":
+		return chart.Grid{}, nil
+	case "major":
+		return chart.Grid{Major: true}, nil
+	case "minor":
+		return chart.Grid{Minor: true}, nil
+	case "all":
+		return chart.Grid{Major: true, Minor: true}, nil
+	default:
+		return chart.Grid{}, fmt.Errorf("invalid %s: %s, must be one of none, major, minor or all", name, value)
+	}
+}
+
 type params struct {
-	Owner      string
-	Repo       string
-	Line       string
-	Background string
-	Axis       string
-	Variant    string
+	Owner       string
+	Repo        string
+	Line        string
+	Background  string
+	Axis        string
+	Plot        string
+	Theme       chart.Theme
+	Width       int
+	Height      int
+	Sparkline   bool
+	Grid        chart.Grid
+	XGrid       chart.Grid
+	Title       string
+	Legend      bool
+	Lang        string
+	Interactive bool
+	Smooth      bool
+	Animate     bool
+	Markers     bool
+	Forecast    *chart.Forecast
+	DateRange   dateRange
+	XAxis       string
+	YAxis       string
+	// YAxisPosition is the side of the primary Y axis, the secondary one
+	// is drawn on the other side.
+	YAxisPosition string
+	Compare       string
 }
 
-func extractSvgChartParams(r *http.Request) (*params, error) {
+func extractSvgChartParams(r *http.Request, sparkline bool) (*params, error) {
 	backgroundColor, err := extractColor(r, "background")
 	if err != nil {
 		return nil, err
@@ -55,15 +339,150 @@ func extractSvgChartParams(r *http.Request) (*params, error) {
 		return nil, err
 	}
 
+	plotColor, err := extractColor(r, "plot")
+	if err != nil {
+		return nil, err
+	}
+
+	grid, err := extractGrid(r, "grid")
+	if err != nil {
+		return nil, err
+	}
+
+	xGrid, err := extractGrid(r, "xgrid")
+	if err != nil {
+		return nil, err
+	}
+
+	legend, err := extractBool(r, "legend")
+	if err != nil {
+		return nil, err
+	}
+
+	interactive, err := extractBool(r, "interactive")
+	if err != nil {
+		return nil, err
+	}
+
+	smooth, err := extractBool(r, "smooth")
+	if err != nil {
+		return nil, err
+	}
+
+	animate, err := extractBool(r, "animate")
+	if err != nil {
+		return nil, err
+	}
+
+	markers, err := extractBool(r, "markers")
+	if err != nil {
+		return nil, err
+	}
+
+	forecast, err := extractForecast(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dateRange, err := extractDateRange(r)
+	if err != nil {
+		return nil, err
+	}
+
+	xAxis, err := extractChoice(r, "xaxis", X_AXIS_DATE, X_AXIS_DAYS)
+	if err != nil {
+		return nil, err
+	}
+
+	yAxis, err := extractChoice(r, "yaxis", Y_AXIS_COUNT, Y_AXIS_PERCENT)
+	if err != nil {
+		return nil, err
+	}
+
+	theme, err := extractTheme(r)
+	if err != nil {
+		return nil, err
+	}
+
+	lang, err := extractLang(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var compare string
+	if !sparkline {
+		compare, err = extractCompare(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// comparisons default to the primary axis on the left, so each series
+	// has an axis next to its legend entry.
+	positions := []string{Y_AXIS_RIGHT, Y_AXIS_LEFT}
+	if compare != "" {
+		slices.Reverse(positions)
+	}
+	yAxisPosition, err := extractChoice(r, "yaxis_position", positions...)
+	if err != nil {
+		return nil, err
+	}
+
 	vars := mux.Vars(r)
 
+	title, err := extractTitle(r, fmt.Sprintf("%s/%s", vars["owner"], vars["repo"]))
+	if err != nil {
+		return nil, err
+	}
+
+	var width, height int
+	if sparkline {
+		width, err = extractSize(r, "width", SPARKLINE_WIDTH, MIN_SPARKLINE_WIDTH, MAX_SPARKLINE_WIDTH)
+		if err != nil {
+			return nil, err
+		}
+		height, err = extractSize(r, "height", SPARKLINE_HEIGHT, MIN_SPARKLINE_HEIGHT, MAX_SPARKLINE_HEIGHT)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		width, err = extractSize(r, "width", CHART_WIDTH, MIN_CHART_WIDTH, MAX_CHART_WIDTH)
+		if err != nil {
+			return nil, err
+		}
+		height, err = extractSize(r, "height", CHART_HEIGHT, MIN_CHART_HEIGHT, MAX_CHART_HEIGHT)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &params{
-		Owner:      vars["owner"],
-		Repo:       vars["repo"],
-		Background: backgroundColor,
-		Axis:       axisColor,
-		Line:       lineColor,
-		Variant:    r.URL.Query().Get("variant"),
+		Owner:       vars["owner"],
+		Repo:        vars["repo"],
+		Background:  backgroundColor,
+		Axis:        axisColor,
+		Line:        lineColor,
+		Plot:        plotColor,
+		Theme:       theme,
+		Width:       width,
+		Height:      height,
+		Sparkline:   sparkline,
+		Grid:        grid,
+		XGrid:       xGrid,
+		Title:       title,
+		Legend:      legend,
+		Lang:        lang,
+		Interactive: interactive,
+		Smooth:      smooth,
+		Animate:     animate,
+		Markers:     markers,
+		Forecast:    forecast,
+		DateRange:   dateRange,
+		XAxis:       xAxis,
+		YAxis:       yAxis,
+
+		YAxisPosition: yAxisPosition,
+		Compare:       compare,
 	}, nil
 }
 
@@ -75,14 +494,79 @@ func writeSvgHeaders(w http.ResponseWriter) {
 	header.Add("expires", time.Now().Format(time.RFC1123))
 }
 
+// chartID identifies the chart of the given params, to keep its element ids
+// unique when several charts are inlined in the same page.
+func chartID(params *params) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(chartKey(params)))
+	return fmt.Sprintf("starchart-%08x", h.Sum32())
+}
+
 func chartKey(params *params) string {
+	mode := "chart"
+	if params.Sparkline {
+		mode = "sparkline"
+	}
+
 	return fmt.Sprintf(
-		"%s/%s/[%s][%s][%s][%s]",
+		"%s/%s/%s/[%s][%s][%s][%s][%s][%dx%d][%s][%s][%s][%t][%s][%t][%t][%t][%t][%s][%s][%s,%s][%s][%s]",
 		params.Owner,
 		params.Repo,
-		params.Variant,
+		mode,
+		params.Theme.Name,
 		params.Background,
 		params.Axis,
 		params.Line,
+		params.Plot,
+		params.Width,
+		params.Height,
+		gridKey(params.Grid),
+		gridKey(params.XGrid),
+		params.Title,
+		params.Legend,
+		params.Lang,
+		params.Interactive,
+		params.Smooth,
+		params.Animate,
+		params.Markers,
+		forecastKey(params.Forecast),
+		rangeKey(params.DateRange),
+		params.XAxis,
+		params.YAxis,
+		params.YAxisPosition,
+		params.Compare,
 	)
 }
+
+func rangeKey(dateRange dateRange) string {
+	return fmt.Sprintf("%s,%s,%s", dateRange.From, dateRange.To, dateRange.Window)
+}
+
+func forecastKey(forecast *chart.Forecast) string {
+	if forecast == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s,%s", forecast.Model, forecast.Window)
+}
+
+func gridKey(grid chart.Grid) string {
+	return fmt.Sprintf("%t,%t", grid.Major, grid.Minor)
+}
+
+// formatCount formats n with thousands separators, e.g. 12,345.
+func formatCount(n int) string {
+	digits := strconv.Itoa(n)
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+
+	var sb strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			sb.WriteRune(',')
+		}
+		sb.WriteRune(digit)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestExtractCompare(t *testing.T) {
+	for _, tt := range []struct {
+		query    string
+		expected string
+		valid    bool
+	}{
+		{"", "", true},
+		{"?compare=golang/go", "golang/go", true},
+		{"?compare=https://github.com/golang/go", "golang/go", true},
+		{"?compare=golang", "", false},
+	} {
+		t.Run(tt.query, func(t *testing.T) {
+			is := is.New(t)
+			compare, err := extractCompare(httptest.NewRequest(http.MethodGet, "/a/b.svg"+tt.query, nil))
+			is.Equal(tt.valid, err == nil) // should validate the repository
+			is.Equal(tt.expected, compare) // should normalize the repository
+		})
+	}
+}
+
+func TestChartKey(t *testing.T) {
+	is := is.New(t)
+	key := func(query string) string {
+		params, err := extractSvgChartParams(httptest.NewRequest(http.MethodGet, "/a/b.svg"+query, nil), false)
+		is.NoErr(err)
+		return chartKey(params)
+	}
+	is.Equal(key(""), key("?width=1024&height=400"))  // should default the size
+	is.True(key("?width=480") != key("?width=481"))   // should key the width
+	is.True(key("?height=240") != key("?height=241")) // should key the height
+}
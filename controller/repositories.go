@@ -5,6 +5,7 @@ import (
 	"html/template"
 	"io/fs"
 	"net/http"
+	"time"
 
 	"github.com/caarlos0/httperr"
 	"github.com/caarlos0/starcharts/internal/cache"
@@ -15,6 +16,43 @@ import (
 const (
 	CHART_WIDTH  = 1024
 	CHART_HEIGHT = 400
+
+	MIN_CHART_WIDTH  = 320
+	MAX_CHART_WIDTH  = 4096
+	MIN_CHART_HEIGHT = 160
+	MAX_CHART_HEIGHT = 2048
+
+	SPARKLINE_WIDTH        = 120
+	SPARKLINE_HEIGHT       = 30
+	SPARKLINE_STROKE_WIDTH = 1.5
+
+	// SERIES_TOLERANCE is the distance, in pixels, under which points are
+	// dropped from the rendered series.
+	SERIES_TOLERANCE = 0.5
+
+	MIN_SPARKLINE_WIDTH  = 40
+	MAX_SPARKLINE_WIDTH  = 1024
+	MIN_SPARKLINE_HEIGHT = 10
+	MAX_SPARKLINE_HEIGHT = 400
+
+	MAX_TITLE_LENGTH = 100
+
+	FORECAST_WINDOW = 90 * 24 * time.Hour
+
+	X_AXIS_DATE    = "date"
+	X_AXIS_DAYS    = "days"
+	Y_AXIS_COUNT   = "count"
+	Y_AXIS_PERCENT = "percent"
+	Y_AXIS_LEFT    = "left"
+	Y_AXIS_RIGHT   = "right"
+
+	// CRAWL_WAIT is how long a chart request waits for its crawl before
+	// answering with a placeholder.
+	CRAWL_WAIT = 3 * time.Second
+	// MAX_STATUS_WAIT caps how long a status request may wait for a crawl.
+	MAX_STATUS_WAIT = 30 * time.Second
+	// PLACEHOLDER_MAX_AGE is how long clients may cache a placeholder.
+	PLACEHOLDER_MAX_AGE = 10 * time.Second
 )
 
 // GetRepo shows the given repo chart.
@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/gorilla/mux"
+)
+
+// CountRequests counts the requests of the charted repository, and of the
+// compared one, so the most requested ones are refreshed in the background.
+func CountRequests(cache *cache.Redis, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := fmt.Sprintf("%s/%s", mux.Vars(r)["owner"], mux.Vars(r)["repo"])
+		compare, _ := extractCompare(r)
+		for _, requested := range []string{name, compare} {
+			if !repoExpression.MatchString(requested) {
+				continue
+			}
+			if err := cache.CountRequest(requested); err != nil {
+				slog.Warn("failed to count request", "repo", requested, "error", err)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
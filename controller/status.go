@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/caarlos0/httperr"
+	"github.com/caarlos0/starcharts/internal/crawler"
+	"github.com/gorilla/mux"
+)
+
+// GetRepoStatus returns the status of the given repository crawl as JSON,
+// enqueuing it if needed. With wait, e.g. wait=10s, it waits for the crawl
+// to be done or to fail, so clients may long poll instead of polling.
+func GetRepoStatus(pool *crawler.Pool) http.Handler {
+	return httperr.NewF(func(w http.ResponseWriter, r *http.Request) error {
+		name := fmt.Sprintf(
+			"%s/%s",
+			mux.Vars(r)["owner"],
+			mux.Vars(r)["repo"],
+		)
+		if !repoExpression.MatchString(name) {
+			return httperr.Wrap(fmt.Errorf("invalid repository: %s", name), http.StatusBadRequest)
+		}
+
+		var wait time.Duration
+		if value := r.URL.Query().Get("wait"); value != "" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 || d > MAX_STATUS_WAIT {
+				return httperr.Wrap(fmt.Errorf("invalid wait: %s, must be at most %s", value, MAX_STATUS_WAIT), http.StatusBadRequest)
+			}
+			wait = d
+		}
+
+		status, err := pool.Enqueue(r.Context(), name)
+		if err != nil {
+			return err
+		}
+		if wait > 0 && status.State != crawler.StateDone {
+			if status, err = pool.Wait(r.Context(), name, wait); err != nil {
+				return err
+			}
+		}
+
+		w.Header().Add("content-type", "application/json")
+		w.Header().Add("cache-control", "no-store")
+		return json.NewEncoder(w).Encode(status)
+	})
+}
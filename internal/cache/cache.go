@@ -42,6 +42,9 @@ func init() {
 	prometheus.MustRegister(cacheGets, cachePuts, cacheDeletes)
 }
 
+// requestsKey is the sorted set counting the requests of each repository.
+const requestsKey = "requests"
+
 // Redis cache.
 type Redis struct {
 	redis *redis.Client
@@ -80,6 +83,11 @@ func (c *Redis) Get(key string, result any) error {
 	return nil
 }
 
+// Exists tells whether the key is cached.
+func (c *Redis) Exists(key string) bool {
+	return c.codec.Exists(key)
+}
+
 // Put on cache.
 func (c *Redis) Put(key string, obj any) error {
 	if err := c.codec.Set(&rediscache.Item{
@@ -100,3 +108,26 @@ func (c *Redis) Delete(key string) error {
 	cacheDeletes.Inc()
 	return nil
 }
+
+// CountRequest counts a request for the given repository.
+func (c *Redis) CountRequest(name string) error {
+	return c.redis.ZIncrBy(requestsKey, 1, name).Err()
+}
+
+// MostRequested returns the n most requested repositories, most requested
+// first.
+func (c *Redis) MostRequested(n int) ([]string, error) {
+	return c.redis.ZRevRange(requestsKey, 0, int64(n-1)).Result()
+}
+
+// DecayRequests multiplies every request count by factor, so older requests
+// weigh less than recent ones, and forgets the repositories left with less
+// than one request.
+func (c *Redis) DecayRequests(factor float64) error {
+	if err := c.redis.ZUnionStore(requestsKey, redis.ZStore{
+		Weights: []float64{factor},
+	}, requestsKey).Err(); err != nil {
+		return err
+	}
+	return c.redis.ZRemRangeByScore(requestsKey, "-inf", "(1").Err()
+}
@@ -0,0 +1,133 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/chart/svg"
+)
+
+// DefaultLang is the language used for the accessible summary when none is set.
+const DefaultLang = "en"
+
+type summaryText struct {
+	title    string
+	summary  string
+	point    string
+	forecast string
+}
+
+// summaries are the accessible summary templates by language. The summary
+// arguments are the name, total stars, stars gained, start date, end date
+// and average stars per month. The point arguments, used by the interactive
+// tooltips, are the date and star count. The forecast arguments are the next
+// milestone and its projected date.
+var summaries = map[string]summaryText{
+	"en": {
+		title:    "Stargazers over time",
+		summary:  "%s has %.0f stars. It gained %.0f stars between %s and %s, about %.0f per month.",
+		point:    "%s: %.0f stars",
+		forecast: "%s stars by %s",
+	},
+	"pt": {
+		title:    "Estrelas ao longo do tempo",
+		summary:  "%s tem %.0f estrelas. Ganhou %.0f estrelas entre %s e %s, cerca de %.0f por mês.",
+		point:    "%s: %.0f estrelas",
+		forecast: "%s estrelas em %s",
+	},
+	"es": {
+		title:    "Estrellas a lo largo del tiempo",
+		summary:  "%s tiene %.0f estrellas. Ganó %.0f estrellas entre %s y %s, unas %.0f por mes.",
+		point:    "%s: %.0f estrellas",
+		forecast: "%s estrellas el %s",
+	},
+	"fr": {
+		title:    "Étoiles au fil du temps",
+		summary:  "%s a %.0f étoiles. Il a gagné %.0f étoiles entre le %s et le %s, environ %.0f par mois.",
+		point:    "%s : %.0f étoiles",
+		forecast: "%s étoiles le %s",
+	},
+	"de": {
+		title:    "Sterne im Zeitverlauf",
+		summary:  "%[1]s hat %.0[2]f Sterne. Zwischen %[4]s und %[5]s kamen %.0[3]f Sterne hinzu, etwa %.0[6]f pro Monat.",
+		point:    "%s: %.0f Sterne",
+		forecast: "%s Sterne am %s",
+	},
+}
+
+// SupportedLang returns true if there is an accessible summary for the given language.
+func SupportedLang(lang string) bool {
+	_, ok := summaries[lang]
+	return ok
+}
+
+func (c *Chart) lang() string {
+	if SupportedLang(c.Lang) {
+		return c.Lang
+	}
+	return DefaultLang
+}
+
+// accessibleTitle is the SVG title, which defaults to the series name.
+func (c *Chart) accessibleTitle() string {
+	if card, _ := c.card(); card != nil {
+		return card.Title
+	}
+
+	switch {
+	case c.Title != "":
+		return c.Title
+	case c.Series.Name != "":
+		return c.Series.Name
+	default:
+		return summaries[c.lang()].title
+	}
+}
+
+// summary describes the series: its date range, total stars and growth.
+func (c *Chart) summary() string {
+	if card, _ := c.card(); card != nil {
+		return strings.TrimSpace(card.Message + " " + card.Hint)
+	}
+	if c.Series.Len() == 0 {
+		return summaries[c.lang()].title
+	}
+
+	name := c.Series.Name
+	if name == "" {
+		name = c.accessibleTitle()
+	}
+
+	start, end := c.Series.XValues[0], c.Series.XValues[c.Series.Len()-1]
+	first, last := c.Series.YValues[0], c.Series.YValues[c.Series.Len()-1]
+	growth := last - first
+
+	months := end.Sub(start).Hours() / 24 / 30
+	perMonth := growth
+	if months > 1 {
+		perMonth = math.Round(growth / months)
+	}
+
+	return fmt.Sprintf(
+		summaries[c.lang()].summary,
+		name,
+		last,
+		growth,
+		start.Format(time.DateOnly),
+		end.Format(time.DateOnly),
+		perMonth,
+	)
+}
+
+func (c *Chart) renderAccessibility(e *svg.Encoder) {
+	svg.Title().
+		Attr("id", c.elementID("title")).
+		Content(c.accessibleTitle()).
+		Render(e)
+	svg.Desc().
+		Attr("id", c.elementID("desc")).
+		Content(c.summary()).
+		Render(e)
+}
@@ -0,0 +1,78 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestElementIDs(t *testing.T) {
+	render := func(c *Chart) string {
+		t.Helper()
+		var sb strings.Builder
+		is.New(t).NoErr(c.Render(&sb))
+		return sb.String()
+	}
+	chart := func(name string) *Chart {
+		return &Chart{
+			Width:  1024,
+			Height: 400,
+			Series: Series{
+				Name:    name,
+				XValues: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+				YValues: []float64{1, 10},
+			},
+		}
+	}
+
+	t.Run("given id", func(t *testing.T) {
+		is := is.New(t)
+		c := chart("a/a")
+		c.ID = "chart"
+		out := render(c)
+		is.True(strings.Contains(out, `aria-labelledby="chart-title"`))     // should be labelled by the title
+		is.True(strings.Contains(out, `aria-describedby="chart-desc"`))     // should be described by the summary
+		is.True(strings.Contains(out, `<title id="chart-title">`))          // should prefix the title id
+		is.True(strings.Contains(out, `<desc id="chart-desc">`))            // should prefix the summary id
+		is.True(strings.Contains(out, `<clipPath id="chart-plot-clip">`))   // should prefix the clip path id
+		is.True(strings.Contains(out, `clip-path="url(#chart-plot-clip)"`)) // should clip with its own clip path
+	})
+
+	t.Run("derived id", func(t *testing.T) {
+		is := is.New(t)
+		a, b := chart("a/a"), chart("b/b")
+		is.Equal(a.id(), chart("a/a").id())                           // should be stable
+		is.True(a.id() != b.id())                                     // should differ between charts
+		is.True(strings.Contains(render(a), `id="`+a.id()+`-title"`)) // should prefix the title id
+	})
+}
+
+func TestSummary(t *testing.T) {
+	series := Series{
+		Name:    "test/test",
+		XValues: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		YValues: []float64{10, 130},
+	}
+	for lang, expected := range map[string]string{
+		"en": "test/test has 130 stars. It gained 120 stars between 2021-01-01 and 2022-01-01, about 10 per month.",
+		"pt": "test/test tem 130 estrelas. Ganhou 120 estrelas entre 2021-01-01 e 2022-01-01, cerca de 10 por mês.",
+		"es": "test/test tiene 130 estrellas. Ganó 120 estrellas entre 2021-01-01 y 2022-01-01, unas 10 por mes.",
+		"fr": "test/test a 130 étoiles. Il a gagné 120 étoiles entre le 2021-01-01 et le 2022-01-01, environ 10 par mois.",
+		"de": "test/test hat 130 Sterne. Zwischen 2021-01-01 und 2022-01-01 kamen 120 Sterne hinzu, etwa 10 pro Monat.",
+		"xx": "test/test has 130 stars. It gained 120 stars between 2021-01-01 and 2022-01-01, about 10 per month.",
+	} {
+		t.Run(lang, func(t *testing.T) {
+			is := is.New(t)
+			c := &Chart{ID: "chart", Width: 1024, Height: 400, Lang: lang, Series: series}
+			is.Equal(expected, c.summary()) // should localize the summary
+
+			var sb strings.Builder
+			is.NoErr(c.Render(&sb))
+			out := sb.String()
+			is.True(strings.Contains(out, `<desc id="chart-desc">`+expected+`</desc>`)) // should render the summary
+			is.True(strings.Contains(out, ` lang="`+c.lang()+`"`))                      // should set the language, falling back to english
+		})
+	}
+}
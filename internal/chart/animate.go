@@ -0,0 +1,40 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/caarlos0/starcharts/internal/chart/svg"
+)
+
+// AnimationDuration is how long, in seconds, an animated series takes to
+// draw itself.
+const AnimationDuration = 2.0
+
+// animationCSS draws the animated series from start to end by moving a dash
+// as long as the line, then fades in the forecast and markers. Viewers
+// preferring reduced motion get the static chart.
+var animationCSS = fmt.Sprintf(`
+@keyframes draw { to { stroke-dashoffset: 0; } }
+@keyframes appear { from { opacity: 0; } }
+path.series.animated { animation: draw %[1]gs ease-out forwards; }
+.animated ~ .forecast, .animated ~ .markers { animation: appear 0.5s ease-in %[1]gs both; }
+
+@media (prefers-reduced-motion: reduce) {
+	path.series.animated { animation: none; stroke-dasharray: none; }
+	.animated ~ .forecast, .animated ~ .markers { animation: none; }
+}
+`, AnimationDuration)
+
+func (c *Chart) animated() bool {
+	return c.Series.Animate || (c.hasSecondary() && c.SecondarySeries.Animate)
+}
+
+// animate hides the path behind a dash offset by its whole length, which
+// the animation then moves back to zero.
+func animate(path *svg.PathBuilder, vertices []svg.Vertex, smooth bool) {
+	length := svg.Point(math.Ceil(svg.Length(vertices, smooth)))
+	path.
+		Attr("stroke-dasharray", length).
+		Attr("stroke-dashoffset", length)
+}
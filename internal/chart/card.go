@@ -0,0 +1,87 @@
+package chart
+
+import "github.com/caarlos0/starcharts/internal/chart/svg"
+
+// Card is a short text rendered in place of the chart, styled with the chart
+// theme, e.g. when it couldn't be drawn.
+type Card struct {
+	Title   string
+	Message string
+	// Hint is an optional last line, e.g. when to try again.
+	Hint string
+}
+
+type cardLine struct {
+	class string
+	text  string
+	size  float64
+}
+
+// card returns the card rendered instead of the chart, if any, and its kind,
+// used to style it.
+func (c *Chart) card() (*Card, string) {
+	switch {
+	case c.Error != nil:
+		return c.Error, "error"
+	case c.Placeholder != nil:
+		return c.Placeholder, "placeholder"
+	default:
+		return nil, ""
+	}
+}
+
+// lines returns the card lines fitting in height, dropping the last ones
+// first. Sparklines usually only fit the title.
+func (card *Card) lines(kind string, height int, sparkline bool) ([]cardLine, int) {
+	titleSize := TitleFontSize
+	if sparkline {
+		titleSize = AxisFontSize
+	}
+
+	var lines []cardLine
+	var total int
+	for _, line := range []cardLine{
+		{kind + "-title", card.Title, titleSize},
+		{kind + "-message", card.Message, AxisFontSize},
+		{kind + "-hint", card.Hint, AxisFontSize},
+	} {
+		if line.text == "" {
+			continue
+		}
+		tb := measureText(line.text, line.size)
+		lineHeight := tb.Height() + TitleMargin
+		if len(lines) > 0 && total+lineHeight > height {
+			break
+		}
+		lines = append(lines, line)
+		total += lineHeight
+	}
+	return lines, total - TitleMargin
+}
+
+func (c *Chart) renderCard(e *svg.Encoder) {
+	radius := c.theme().CornerRadius
+	if c.Sparkline {
+		radius = min(radius, SparklineBackgroundRadius)
+	}
+
+	card, kind := c.card()
+	lines, height := card.lines(kind, c.Height, c.Sparkline)
+	y := (c.Height - height) >> 1
+
+	c.renderSVG(e, radius, func(e *svg.Encoder) {
+		for _, line := range lines {
+			tb := measureText(line.text, line.size)
+			y += tb.Height()
+			svg.Text().
+				Attr("class", line.class).
+				Attr("style", fontSize(line.size)).
+				Attr("x", svg.Point(c.Width>>1)).
+				Attr("y", svg.Point(y)).
+				Attr("text-anchor", "middle").
+				Content(line.text).
+				Render(e)
+			y += TitleMargin
+		}
+	})
+}
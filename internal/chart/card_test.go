@@ -0,0 +1,36 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCard(t *testing.T) {
+	card := &Card{Title: "Not found", Message: "Check the names.", Hint: "Or not."}
+
+	t.Run("chart", func(t *testing.T) {
+		is := is.New(t)
+		var sb strings.Builder
+		is.NoErr((&Chart{ID: "card", Width: 1024, Height: 400, Error: card}).Render(&sb))
+		out := sb.String()
+		is.True(strings.Contains(out, `<title id="card-title">Not found</title>`)) // should title the svg with the error
+		is.Equal(3, strings.Count(out, "<text"))                                   // should render every line
+		is.True(!strings.Contains(out, "<path"))                                   // should not render the series
+	})
+
+	t.Run("placeholder", func(t *testing.T) {
+		is := is.New(t)
+		var sb strings.Builder
+		is.NoErr((&Chart{Width: 1024, Height: 400, Placeholder: card}).Render(&sb))
+		is.True(strings.Contains(sb.String(), `class="placeholder-title"`)) // should style it as a placeholder
+	})
+
+	t.Run("sparkline", func(t *testing.T) {
+		is := is.New(t)
+		var sb strings.Builder
+		is.NoErr((&Chart{Width: 120, Height: 30, Sparkline: true, Error: card}).Render(&sb))
+		is.Equal(1, strings.Count(sb.String(), "<text")) // should only fit the title
+	})
+}
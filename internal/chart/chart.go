@@ -1,16 +1,76 @@
 package chart
 
+import (
+	"fmt"
+	"hash/fnv"
+)
+
 type ValueFormatter func(v any) string
 
 type Chart struct {
+	// ID prefixes the ids of the chart elements, so they don't collide when
+	// several charts are inlined in the same page. Derived from the chart
+	// when empty.
+	ID string
+
+	Title    string
+	Subtitle string
+	// Legend shows the series names above the plot.
+	Legend bool
+
 	XAxis XAxis
 	YAxis YAxis
 
 	Series Series
 
-	Background string
-	Styles     string
+	// SecondarySeries is plotted against SecondaryYAxis, on its own range,
+	// so series of very different scales can be compared.
+	SecondarySeries *Series
+	SecondaryYAxis  YAxis
+
+	Background     string
+	PlotBackground string
+	// Theme is the chart look, defaults to LightTheme.
+	Theme *Theme
 
 	Width  int
 	Height int
+
+	// Sparkline renders only the series, without axes, labels or padding.
+	Sparkline bool
+
+	// Forecast projects the series growth until its next milestone.
+	Forecast *Forecast
+
+	// Interactive adds hover tooltips with the exact date and star count of
+	// each data point.
+	Interactive bool
+
+	// Lang is the language of the accessible summary, defaults to DefaultLang.
+	Lang string
+
+	// Error, when set, is rendered instead of the series.
+	Error *Card
+	// Placeholder, when set, is rendered instead of the series while they
+	// are being fetched.
+	Placeholder *Card
+}
+
+// id returns the chart ID, or one derived from its title, series, size and
+// theme.
+func (c *Chart) id() string {
+	if c.ID != "" {
+		return c.ID
+	}
+	h := fnv.New32a()
+	_, _ = fmt.Fprint(h, c.Title, c.Subtitle, c.Series.Name, c.Series.Len(), c.Width, c.Height, c.theme().Name, c.Sparkline)
+	if card, _ := c.card(); card != nil {
+		_, _ = fmt.Fprint(h, card.Title)
+	}
+	return fmt.Sprintf("starchart-%08x", h.Sum32())
+}
+
+// elementID returns the id of the named chart element.
+func (c *Chart) elementID(name string) string {
+	return c.id() + "-" + name
 }
@@ -12,7 +12,10 @@ const (
 
 	DefaultTickCountSanityCheck = 1024
 
-	AxisFontSize = 10.0
+	AxisFontSize     = 10.0
+	TitleFontSize    = 14.0
+	SubtitleFontSize = 10.0
+	LegendFontSize   = 10.0
 
 	MinimumTickHorizontalSpacing = 20
 	MinimumTickVerticalSpacing   = 20
@@ -20,8 +23,20 @@ const (
 	YAxisMargin = 10
 	XAxisMargin = 10
 
+	TitleMargin = 6
+
+	LegendSwatchWidth = 16
+	LegendMargin      = 6
+
 	VerticalTickHeight  = XAxisMargin >> 1
 	HorizontalTickWidth = YAxisMargin >> 1
 
 	MinStrokeWidth = 1.0
+
+	// MarkerScale is the radius of the sample markers relative to the
+	// series stroke width.
+	MarkerScale = 1.5
+
+	BackgroundRadius          = 8
+	SparklineBackgroundRadius = 4
 )
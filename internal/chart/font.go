@@ -1,29 +1,161 @@
 package chart
 
 import (
+	"bytes"
 	_ "embed"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
 	"sync"
+	"sync/atomic"
 
-	"github.com/golang/freetype/truetype"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
 )
 
 var (
 	//go:embed Roboto-Medium.ttf
-	roboto   []byte
-	fontLock sync.Mutex
-	fontDef  *truetype.Font
-)
+	roboto  []byte
+	fontDef atomic.Pointer[Font]
 
-func GetFont() *truetype.Font {
-	if fontDef == nil {
-		fontLock.Lock()
-		defer fontLock.Unlock()
-		var err error
-		fontDef, err = truetype.Parse(roboto)
+	defaultFont = sync.OnceValue(func() *Font {
+		f, err := NewFont(roboto, "Roboto Medium", false)
 		if err != nil {
 			panic(err)
 		}
+		return f
+	})
+)
+
+// Font is the typeface used both to measure and to render the chart text.
+type Font struct {
+	// Family is the CSS font family name.
+	Family string
+	// Embed adds the glyphs used by each chart to its SVG as a @font-face
+	// data URI, so it renders even where the font isn't installed.
+	Embed bool
+
+	data []byte
+	font *opentype.Font
+
+	// faces are pools of faces by size, as faces are not safe for
+	// concurrent use.
+	faces sync.Map
+}
+
+// NewFont parses a TrueType or OpenType font. When family is empty, the
+// family name is read from the font itself. Only TrueType fonts can be
+// embedded, as embedded fonts are subset to the glyphs each chart uses.
+func NewFont(data []byte, family string, embed bool) (*Font, error) {
+	parsed, err := opentype.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse font: %w", err)
+	}
+
+	if embed && bytes.HasPrefix(data, []byte("OTTO")) {
+		return nil, errors.New("only TrueType fonts can be embedded")
+	}
+
+	if family == "" {
+		family, err = parsed.Name(nil, sfnt.NameIDFamily)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font family: %w", err)
+		}
+	}
+
+	return &Font{
+		Family: family,
+		Embed:  embed,
+		data:   data,
+		font:   parsed,
+	}, nil
+}
+
+// LoadFont reads a TrueType or OpenType font file and uses it for all charts.
+func LoadFont(path, family string, embed bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read font: %w", err)
+	}
+
+	f, err := NewFont(data, family, embed)
+	if err != nil {
+		return err
+	}
+
+	fontDef.Store(f)
+	return nil
+}
+
+// GetFont returns the font used for all charts, which defaults to the
+// embedded Roboto Medium.
+func GetFont() *Font {
+	if f := fontDef.Load(); f != nil {
+		return f
+	}
+	return defaultFont()
+}
+
+// MeasureString returns the advance width of the text at the given size.
+func (f *Font) MeasureString(body string, size float64) fixed.Int26_6 {
+	pool, _ := f.faces.LoadOrStore(size, &sync.Pool{
+		New: func() any {
+			face, err := opentype.NewFace(f.font, &opentype.FaceOptions{
+				DPI:  DPI,
+				Size: size,
+			})
+			if err != nil {
+				panic(err)
+			}
+			return face
+		},
+	})
+	faces := pool.(*sync.Pool)
+	face := faces.Get().(font.Face)
+	defer faces.Put(face)
+	return font.MeasureString(face, body)
+}
+
+// CSSFamily returns the font-family CSS value.
+func (f *Font) CSSFamily() string {
+	return fmt.Sprintf("'%s', sans-serif", f.Family)
+}
+
+// FontFace returns the @font-face CSS rule embedding the font, subset to the
+// glyphs of the printable ASCII characters and of text, or an empty string if
+// it should not be embedded.
+func (f *Font) FontFace(text string) string {
+	if !f.Embed {
+		return ""
+	}
+
+	var buf sfnt.Buffer
+	var glyphs []uint16
+	add := func(r rune) {
+		if g, err := f.font.GlyphIndex(&buf, r); err == nil && g != 0 {
+			glyphs = append(glyphs, uint16(g))
+		}
+	}
+	for r := rune(' '); r <= '~'; r++ {
+		add(r)
+	}
+	for _, r := range text {
+		add(r)
+	}
+
+	data, err := subsetTrueType(f.data, glyphs)
+	if err != nil {
+		slog.Warn("failed to subset font, embedding it whole", "error", err)
+		data = f.data
 	}
 
-	return fontDef
+	return fmt.Sprintf(
+		"@font-face { font-family: '%s'; src: url(data:font/ttf;base64,%s) format('truetype'); }\n",
+		f.Family,
+		base64.StdEncoding.EncodeToString(data),
+	)
 }
@@ -0,0 +1,74 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestNewFont(t *testing.T) {
+	t.Run("family from font", func(t *testing.T) {
+		is := is.New(t)
+		f, err := NewFont(roboto, "", false)
+		is.NoErr(err)                       // should parse the font
+		is.Equal("Roboto Medium", f.Family) // should read the family name
+		is.Equal("", f.FontFace(""))        // should not embed the font
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		is := is.New(t)
+		f, err := NewFont(roboto, "Brand", true)
+		is.NoErr(err)                                                                                                    // should parse the font
+		is.True(strings.HasPrefix(f.FontFace("é"), "@font-face { font-family: 'Brand'; src: url(data:font/ttf;base64,")) // should embed the font
+	})
+
+	t.Run("embedded opentype", func(t *testing.T) {
+		is := is.New(t)
+		otf := append([]byte("OTTO"), roboto[4:]...)
+		_, err := NewFont(otf, "", true)
+		is.True(err != nil) // should only embed truetype fonts
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		is := is.New(t)
+		_, err := NewFont([]byte("not a font"), "", false)
+		is.True(err != nil) // should fail to parse
+	})
+}
+
+func TestSubsetTrueType(t *testing.T) {
+	is := is.New(t)
+	f, err := NewFont(roboto, "", false)
+	is.NoErr(err)
+
+	var buf sfnt.Buffer
+	glyph := func(font *sfnt.Font, r rune) sfnt.GlyphIndex {
+		g, err := font.GlyphIndex(&buf, r)
+		is.NoErr(err)
+		return g
+	}
+	outline := func(font *sfnt.Font, g sfnt.GlyphIndex) int {
+		segments, err := font.LoadGlyph(&buf, g, fixed.I(12), nil)
+		is.NoErr(err)
+		return len(segments)
+	}
+
+	// é is a composite glyph, made of e and an accent.
+	a, eAcute := glyph(f.font, 'a'), glyph(f.font, 'é')
+	data, err := subsetTrueType(roboto, []uint16{uint16(a), uint16(eAcute)})
+	is.NoErr(err)
+	is.True(len(data) < len(roboto)/2) // should be much smaller
+
+	is.Equal(uint32(checksumMagic), checksum(data)) // should adjust the font checksum
+
+	subset, err := sfnt.Parse(data)
+	is.NoErr(err)                                              // should be a valid font
+	is.Equal(f.font.NumGlyphs(), subset.NumGlyphs())           // should keep the glyph ids
+	is.Equal(outline(f.font, a), outline(subset, a))           // should keep the used glyphs
+	is.Equal(outline(f.font, eAcute), outline(subset, eAcute)) // should keep the composite glyphs components
+	is.Equal(0, outline(subset, glyph(subset, 'z')))           // should drop the unused glyphs
+	is.Equal(glyph(f.font, 'z'), glyph(subset, 'z'))           // should keep the character map
+}
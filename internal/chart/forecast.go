@@ -0,0 +1,214 @@
+package chart
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/chart/svg"
+)
+
+// ForecastModel is the curve fitted to the recent growth of a series.
+type ForecastModel string
+
+const (
+	LinearForecast      ForecastModel = "linear"
+	ExponentialForecast ForecastModel = "exponential"
+)
+
+const (
+	// DefaultForecastHorizon limits how far into the future the projection
+	// is drawn when no horizon is set.
+	DefaultForecastHorizon = 365 * 24 * time.Hour
+
+	forecastSamples = 24
+	dayDuration     = 24 * time.Hour
+)
+
+// Forecast configures a projection of the series growth.
+type Forecast struct {
+	Model ForecastModel
+	// Window is how far back from the last point the fit looks.
+	Window time.Duration
+	// Horizon is how far into the future the projection is drawn.
+	Horizon time.Duration
+}
+
+// projection is a fitted forecast, ready to be rendered.
+type projection struct {
+	Series    Series
+	Milestone float64
+	// MilestoneAt is when the milestone is projected to be reached.
+	MilestoneAt time.Time
+}
+
+// fit is a fitted curve, with x in days relative to the last point. at
+// returns the value at x, and until returns the x at which y is reached.
+type fit struct {
+	at    func(x float64) float64
+	until func(y float64) float64
+}
+
+func (f Forecast) fit(xs, ys []float64) (fit, bool) {
+	switch f.Model {
+	case ExponentialForecast:
+		var lxs, lys []float64
+		for i, y := range ys {
+			if y > 0 {
+				lxs = append(lxs, xs[i])
+				lys = append(lys, math.Log(y))
+			}
+		}
+		a, b, ok := leastSquares(lxs, lys)
+		if !ok || b <= 0 {
+			return fit{}, false
+		}
+		return fit{
+			at:    func(x float64) float64 { return math.Exp(a + b*x) },
+			until: func(y float64) float64 { return (math.Log(y) - a) / b },
+		}, true
+	default:
+		a, b, ok := leastSquares(xs, ys)
+		if !ok || b <= 0 {
+			return fit{}, false
+		}
+		return fit{
+			at:    func(x float64) float64 { return a + b*x },
+			until: func(y float64) float64 { return (y - a) / b },
+		}, true
+	}
+}
+
+// leastSquares fits y = a + b*x.
+func leastSquares(xs, ys []float64) (a, b float64, ok bool) {
+	if len(xs) < 2 {
+		return 0, 0, false
+	}
+
+	mx, my := mean(xs...), mean(ys...)
+	var sxy, sxx float64
+	for i := range xs {
+		sxy += (xs[i] - mx) * (ys[i] - my)
+		sxx += (xs[i] - mx) * (xs[i] - mx)
+	}
+	if sxx == 0 {
+		return 0, 0, false
+	}
+
+	b = sxy / sxx
+	return my - b*mx, b, true
+}
+
+// nextMilestone returns the first 1, 2 or 5 times a power of ten above value.
+func nextMilestone(value float64) float64 {
+	for magnitude := 1.0; ; magnitude *= 10 {
+		for _, step := range []float64{1, 2, 5} {
+			if milestone := step * magnitude; milestone > value {
+				return milestone
+			}
+		}
+	}
+}
+
+// project fits the forecast model over the recent points of the series and
+// projects it until the next milestone or the horizon, whichever is first.
+func (f Forecast) project(series *Series) (*projection, bool) {
+	if series.Len() < 2 {
+		return nil, false
+	}
+
+	last := series.XValues[series.Len()-1]
+	lastValue := series.YValues[series.Len()-1]
+
+	var xs, ys []float64
+	for i, t := range series.XValues {
+		if f.Window > 0 && last.Sub(t) > f.Window {
+			continue
+		}
+		xs = append(xs, float64(t.Sub(last))/float64(dayDuration))
+		ys = append(ys, series.YValues[i])
+	}
+	// not enough recent points, use the last two
+	if len(xs) < 2 {
+		xs, ys = nil, nil
+		for i := series.Len() - 2; i < series.Len(); i++ {
+			xs = append(xs, float64(series.XValues[i].Sub(last))/float64(dayDuration))
+			ys = append(ys, series.YValues[i])
+		}
+	}
+
+	fitted, ok := f.fit(xs, ys)
+	if !ok {
+		return nil, false
+	}
+
+	milestone := nextMilestone(lastValue)
+	milestoneDays := fitted.until(milestone)
+	if math.IsNaN(milestoneDays) || math.IsInf(milestoneDays, 0) || milestoneDays <= 0 {
+		return nil, false
+	}
+
+	horizon := f.Horizon
+	if horizon <= 0 {
+		horizon = DefaultForecastHorizon
+	}
+	endDays := min(milestoneDays, float64(horizon)/float64(dayDuration))
+
+	result := &projection{
+		Milestone:   milestone,
+		MilestoneAt: last.Add(time.Duration(milestoneDays * float64(dayDuration))),
+		Series: Series{
+			Name:        series.Name,
+			StrokeWidth: series.StrokeWidth,
+			Color:       series.Color,
+			Total:       series.Total,
+			XValues:     []time.Time{last},
+			YValues:     []float64{lastValue},
+		},
+	}
+
+	// start from the last actual value, so the projection is continuous
+	offset := lastValue - fitted.at(0)
+	for i := 1; i <= forecastSamples; i++ {
+		x := endDays * float64(i) / forecastSamples
+		result.Series.XValues = append(result.Series.XValues, last.Add(time.Duration(x*float64(dayDuration))))
+		result.Series.YValues = append(result.Series.YValues, fitted.at(x)+offset*(1-float64(i)/forecastSamples))
+	}
+
+	return result, true
+}
+
+// Render renders the projected line.
+func (p *projection) Render(e *svg.Encoder, canvasBox *Box, xrange, yrange *Range) {
+	points := p.Series.Points(canvasBox, xrange, yrange)
+
+	path := svg.Path().
+		Attr("stroke-width", normaliseStrokeWidth(p.Series.StrokeWidth)).
+		Attr("style", styles("stroke", p.Series.Color)).
+		Attr("class", "forecast").
+		MoveTo(points[0].X, points[0].Y)
+	for _, point := range points[1:] {
+		path.LineTo(point.X, point.Y)
+	}
+	path.Render(e)
+}
+
+// RenderLabel renders the milestone label at the end of the projection,
+// clamped to the plot, so it isn't clipped with the line.
+func (p *projection) RenderLabel(e *svg.Encoder, canvasBox *Box, xrange, yrange *Range, lang string) {
+	label := fmt.Sprintf(
+		summaries[lang].forecast,
+		CompactValueFormatter(p.Milestone),
+		p.MilestoneAt.Format(time.DateOnly),
+	)
+
+	points := p.Series.Points(canvasBox, xrange, yrange)
+	end := points[len(points)-1]
+	tb := measureText(label, AxisFontSize)
+	svg.Text().
+		Attr("class", "forecast").
+		Attr("x", svg.Point(max(min(end.X, canvasBox.Right)-tb.Width(), canvasBox.Left))).
+		Attr("y", svg.Point(max(end.Y-XAxisMargin, canvasBox.Top+tb.Height()))).
+		Content(label).
+		Render(e)
+}
@@ -0,0 +1,96 @@
+package chart
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestNextMilestone(t *testing.T) {
+	for value, expected := range map[float64]float64{
+		0:     1,
+		3:     5,
+		7300:  10000,
+		10000: 20000,
+		45000: 50000,
+	} {
+		is := is.New(t)
+		is.Equal(expected, nextMilestone(value)) // should find the next milestone
+	}
+}
+
+func TestForecastProject(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	series := Series{}
+	for i := range 100 {
+		series.XValues = append(series.XValues, start.AddDate(0, 0, i))
+		series.YValues = append(series.YValues, float64(100+i*10))
+	}
+
+	t.Run("linear", func(t *testing.T) {
+		is := is.New(t)
+		p, ok := Forecast{Model: LinearForecast, Window: 30 * dayDuration}.project(&series)
+		is.True(ok)                                                           // should project
+		is.Equal(2000.0, p.Milestone)                                         // should target the next milestone
+		is.Equal(start.AddDate(0, 0, 190), p.MilestoneAt.Truncate(time.Hour)) // should reach it at the same pace
+		is.Equal(p.Series.XValues[0], series.XValues[99])                     // should start at the last point
+		is.Equal(p.Series.YValues[len(p.Series.YValues)-1], 2000.0)           // should end at the milestone
+	})
+
+	t.Run("horizon", func(t *testing.T) {
+		is := is.New(t)
+		p, ok := Forecast{Model: LinearForecast, Horizon: 30 * dayDuration}.project(&series)
+		is.True(ok)                                                                               // should project
+		is.Equal(series.XValues[99].AddDate(0, 0, 30), p.Series.XValues[len(p.Series.XValues)-1]) // should stop at the horizon
+	})
+
+	t.Run("exponential", func(t *testing.T) {
+		is := is.New(t)
+		p, ok := Forecast{Model: ExponentialForecast, Window: 30 * dayDuration}.project(&series)
+		is.True(ok)                                             // should project
+		is.True(p.MilestoneAt.Before(start.AddDate(0, 0, 190))) // should grow faster than linear
+	})
+
+	t.Run("no growth", func(t *testing.T) {
+		is := is.New(t)
+		flat := Series{
+			XValues: []time.Time{start, start.AddDate(0, 0, 1)},
+			YValues: []float64{10, 10},
+		}
+		_, ok := Forecast{Model: LinearForecast}.project(&flat)
+		is.True(!ok) // should not project a flat series
+	})
+}
+
+func TestForecastLabel(t *testing.T) {
+	is := is.New(t)
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	series := Series{}
+	for i := range 100 {
+		series.XValues = append(series.XValues, start.AddDate(0, 0, i))
+		series.YValues = append(series.YValues, float64(100+i*10))
+	}
+	c := &Chart{
+		Width:    200,
+		Height:   200,
+		Series:   series,
+		Forecast: &Forecast{Model: LinearForecast, Window: 30 * dayDuration},
+	}
+
+	var sb strings.Builder
+	is.NoErr(c.Render(&sb))
+	out := sb.String()
+
+	match := regexp.MustCompile(`<text class="forecast" x="(-?\d+)"`).FindStringSubmatch(out)
+	is.True(match != nil) // should render the milestone label
+	x, _ := strconv.Atoi(match[1])
+	is.True(x >= BoxPadding.Left) // should clamp the label to the plot
+
+	layer := out[strings.Index(out, `class="series-layer"`):]
+	layer = layer[:strings.Index(layer, "</g>")]
+	is.True(!strings.Contains(layer, `<text class="forecast"`)) // should not clip the label
+}
@@ -0,0 +1,73 @@
+package chart
+
+import "github.com/caarlos0/starcharts/internal/chart/svg"
+
+// Grid configures the grid lines drawn across the plot at an axis ticks.
+type Grid struct {
+	// Major draws a line at every tick.
+	Major bool
+	// Minor draws a line halfway between every pair of ticks.
+	Minor bool
+}
+
+func (g Grid) values(ticks []Tick) (major, minor []float64) {
+	for i, t := range ticks {
+		if g.Major {
+			major = append(major, t.Value)
+		}
+		if g.Minor && i > 0 {
+			minor = append(minor, mean(ticks[i-1].Value, t.Value))
+		}
+	}
+	return
+}
+
+func (g Grid) renderHorizontal(e *svg.Encoder, canvasBox *Box, ra *Range, ticks []Tick) {
+	major, minor := g.values(ticks)
+	if len(major)+len(minor) == 0 {
+		return
+	}
+
+	svg.G().Attr("class", "grid").RenderChildren(e, func(e *svg.Encoder) {
+		for _, lines := range []struct {
+			class  string
+			values []float64
+		}{{"grid-minor", minor}, {"grid-major", major}} {
+			for _, v := range lines.values {
+				y := svg.Point(canvasBox.Bottom - ra.Translate(v))
+				svg.Line().
+					Attr("class", lines.class).
+					Attr("x1", svg.Point(canvasBox.Left)).
+					Attr("y1", y).
+					Attr("x2", svg.Point(canvasBox.Right)).
+					Attr("y2", y).
+					Render(e)
+			}
+		}
+	})
+}
+
+func (g Grid) renderVertical(e *svg.Encoder, canvasBox *Box, ra *Range, ticks []Tick) {
+	major, minor := g.values(ticks)
+	if len(major)+len(minor) == 0 {
+		return
+	}
+
+	svg.G().Attr("class", "grid").RenderChildren(e, func(e *svg.Encoder) {
+		for _, lines := range []struct {
+			class  string
+			values []float64
+		}{{"grid-minor", minor}, {"grid-major", major}} {
+			for _, v := range lines.values {
+				x := svg.Point(canvasBox.Left + ra.Translate(v))
+				svg.Line().
+					Attr("class", lines.class).
+					Attr("x1", x).
+					Attr("y1", svg.Point(canvasBox.Top)).
+					Attr("x2", x).
+					Attr("y2", svg.Point(canvasBox.Bottom)).
+					Render(e)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package chart
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGridValues(t *testing.T) {
+	ticks := []Tick{{Value: 0}, {Value: 10}, {Value: 20}}
+	for name, tt := range map[string]struct {
+		grid         Grid
+		major, minor []float64
+	}{
+		"none":  {Grid{}, nil, nil},
+		"major": {Grid{Major: true}, []float64{0, 10, 20}, nil},
+		"minor": {Grid{Minor: true}, nil, []float64{5, 15}},
+		"all":   {Grid{Major: true, Minor: true}, []float64{0, 10, 20}, []float64{5, 15}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			major, minor := tt.grid.values(ticks)
+			is.Equal(tt.major, major) // should draw a major line at every tick
+			is.Equal(tt.minor, minor) // should draw a minor line between ticks
+		})
+	}
+}
+
+func TestRenderGrid(t *testing.T) {
+	is := is.New(t)
+	c := &Chart{
+		Width:  1024,
+		Height: 400,
+		Series: Series{
+			XValues: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			YValues: []float64{1, 10},
+		},
+		YAxis: YAxis{Grid: Grid{Major: true, Minor: true}},
+		XAxis: XAxis{Grid: Grid{Major: true}},
+	}
+	var sb strings.Builder
+	is.NoErr(c.Render(&sb))
+	out := sb.String()
+
+	horizontal := regexp.MustCompile(`<line class="grid-(major|minor)" x1="\d+" y1="(\d+)" x2="\d+" y2="(\d+)"`)
+	var major, minor int
+	for _, line := range horizontal.FindAllStringSubmatch(out, -1) {
+		if line[2] != line[3] {
+			continue
+		}
+		if line[1] == "major" {
+			major++
+		} else {
+			minor++
+		}
+	}
+	yAxis := out[strings.Index(out, `<g class="y-axis">`):]
+	yTicks := strings.Count(yAxis[:strings.Index(yAxis, "</g>")], "<text") - 1 // but the name
+	is.Equal(yTicks, major)                                                    // should draw a horizontal line at every y tick
+	is.Equal(yTicks-1, minor)                                                  // should draw a horizontal line between y ticks
+	is.True(strings.Contains(out, "line.grid-major {"))                        // should style the grid lines
+
+	vertical := regexp.MustCompile(`<line class="grid-major" x1="(\d+)" y1="\d+" x2="(\d+)"`)
+	var verticals int
+	for _, line := range vertical.FindAllStringSubmatch(out, -1) {
+		if line[1] == line[2] {
+			verticals++
+		}
+	}
+	is.True(verticals > 0) // should draw vertical lines at the x ticks
+}
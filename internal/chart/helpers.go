@@ -2,28 +2,23 @@ package chart
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/starcharts/internal/chart/svg"
-	"github.com/golang/freetype/truetype"
-	"golang.org/x/image/font"
 )
 
 func measureText(body string, size float64) Box {
-	drawer := &font.Drawer{
-		Face: truetype.NewFace(GetFont(), &truetype.Options{
-			DPI:  DPI,
-			Size: size,
-		}),
-	}
-
 	return Box{
-		Right:  drawer.MeasureString(body).Ceil(),
+		Right:  GetFont().MeasureString(body, size).Ceil(),
 		Bottom: int(pointsToPixels(DPI, size)),
 	}
 }
 
-func timeValueFormatter(v any) string {
+// TimeValueFormatter formats unix nanoseconds as a date.
+func TimeValueFormatter(v any) string {
 	dateFormat := "2006-01-02"
 	if typed, isTyped := v.(float64); isTyped {
 		return time.Unix(0, int64(typed)).Format(dateFormat)
@@ -32,12 +27,94 @@ func timeValueFormatter(v any) string {
 	return ""
 }
 
-func intValueFormatter(v any) string {
+// IntValueFormatter formats a value as a raw integer.
+func IntValueFormatter(v any) string {
 	return fmt.Sprintf("%.0f", v)
 }
 
-func rotate(ang float32, x int, y int) string {
-	return fmt.Sprintf("rotate(%0.2f,%d,%d)", ang, x, y)
+// PercentValueFormatter formats a value as a percentage.
+func PercentValueFormatter(v any) string {
+	return fmt.Sprintf("%.0f%%", v)
+}
+
+var compactSuffixes = []string{"", "k", "M", "B", "T"}
+
+// maxCompactDecimals caps the decimals of compact values.
+const maxCompactDecimals = 3
+
+// CompactValueFormatter formats a value as a short human-readable number,
+// e.g. 1.2k, 45k and 1.1M.
+func CompactValueFormatter(v any) string {
+	return compactValue(v, 0)
+}
+
+// CompactStepValueFormatter returns a compact formatter precise enough to
+// tell apart values step apart, e.g. 1.05k and 1.1k for a step of 50.
+func CompactStepValueFormatter(step float64) ValueFormatter {
+	return func(v any) string {
+		return compactValue(v, step)
+	}
+}
+
+// compactValue formats the value compactly. With a step, the decimals are
+// the ones its multiples need, otherwise one below 100 of a unit.
+func compactValue(v any, step float64) string {
+	typed, isTyped := v.(float64)
+	if !isTyped {
+		return ""
+	}
+
+	sign := ""
+	if typed < 0 {
+		sign = "-"
+		typed = -typed
+	}
+
+	unit := 0
+	for typed >= 1000 && unit < len(compactSuffixes)-1 {
+		typed /= 1000
+		unit++
+	}
+	decimals := compactDecimals(typed, unit, step)
+
+	// avoid labels such as 1000k when rounding up
+	pow := math.Pow10(decimals)
+	if math.Round(typed*pow)/pow >= 1000 && unit < len(compactSuffixes)-1 {
+		typed /= 1000
+		unit++
+		decimals = compactDecimals(typed, unit, step)
+	}
+
+	value := strconv.FormatFloat(typed, 'f', decimals, 64)
+	if decimals > 0 {
+		value = strings.TrimRight(strings.TrimRight(value, "0"), ".")
+	}
+
+	return sign + value + compactSuffixes[unit]
+}
+
+func compactDecimals(value float64, unit int, step float64) int {
+	switch {
+	case unit == 0:
+		return 0
+	case step > 0:
+		step /= math.Pow(1000, float64(unit))
+		// one decimal more than distinct labels need, unless the step doesn't
+		// have it, e.g. 1.2k and 2.5k for a step of 1234,
+		// or 1.05k and 1.1k for a step of 50.
+		limit := min(max(int(math.Ceil(-math.Log10(step))), 0)+1, maxCompactDecimals)
+		for decimals := range limit {
+			scaled := step * math.Pow10(decimals)
+			if math.Abs(scaled-math.Round(scaled)) < 1e-6*scaled {
+				return decimals
+			}
+		}
+		return limit
+	case value < 100:
+		return 1
+	default:
+		return 0
+	}
 }
 
 func normaliseStrokeWidth(strokeWidth float64) string {
@@ -0,0 +1,71 @@
+package chart
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCompactValueFormatter(t *testing.T) {
+	for value, expected := range map[float64]string{
+		0:          "0",
+		7:          "7",
+		999:        "999",
+		1000:       "1k",
+		1234:       "1.2k",
+		12500:      "12.5k",
+		45000:      "45k",
+		150000:     "150k",
+		999999:     "1M",
+		1100000:    "1.1M",
+		2500000000: "2.5B",
+		-1500:      "-1.5k",
+	} {
+		t.Run(expected, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(expected, CompactValueFormatter(value)) // should format compactly
+		})
+	}
+}
+
+func TestCompactStepValueFormatter(t *testing.T) {
+	for _, tt := range []struct {
+		step     float64
+		value    float64
+		expected string
+	}{
+		{50, 950, "950"},
+		{50, 1050, "1.05k"},
+		{50, 1100, "1.1k"},
+		{50, 100250, "100.25k"},
+		{50, 100400, "100.4k"},
+		{1234, 2468, "2.5k"},
+		{2500, 7500, "7.5k"},
+		{50000, 150000, "150k"},
+		{0, 12500, "12.5k"},
+	} {
+		t.Run(tt.expected, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(tt.expected, CompactStepValueFormatter(tt.step)(tt.value)) // should format for the step
+		})
+	}
+}
+
+func TestGenerateStepTicks(t *testing.T) {
+	for _, rng := range []Range{
+		{Min: 1000, Max: 1500, Domain: 400},
+		{Min: 100000, Max: 100500, Domain: 400},
+	} {
+		t.Run(CompactValueFormatter(rng.Min), func(t *testing.T) {
+			is := is.New(t)
+			ticks := generateStepTicks(&rng, true, (&YAxis{}).formatter)
+			is.Equal(50.0, ticks[1].Value-ticks[0].Value) // should step by 50
+
+			labels := map[string]bool{}
+			for _, tick := range ticks {
+				is.True(!labels[tick.Label]) // should not repeat labels
+				labels[tick.Label] = true
+			}
+		})
+	}
+}
@@ -3,27 +3,153 @@ package chart
 import (
 	"io"
 	"math"
+	"strings"
 
 	"github.com/caarlos0/starcharts/internal/chart/svg"
 )
 
-func (c *Chart) Render(w io.Writer) {
+// Render writes the chart SVG to w as it is rendered, returning the first
+// write error.
+func (c *Chart) Render(w io.Writer) error {
+	e := svg.NewEncoder(w)
+	switch {
+	case c.Error != nil, c.Placeholder != nil:
+		c.renderCard(e)
+	case c.Sparkline:
+		c.renderSparkline(e)
+	default:
+		c.renderChart(e)
+	}
+	return e.Err()
+}
+
+func (c *Chart) renderChart(e *svg.Encoder) {
 	canvas := c.Box()
 
-	xRange, yRange := c.getRanges(canvas)
+	var projection *projection
+	var ranged []*Series
+	if c.Forecast != nil {
+		if p, ok := c.Forecast.project(&c.Series); ok {
+			projection = p
+			ranged = append(ranged, &p.Series)
+		}
+	}
 
-	xTicks := generateTicks(xRange, false, timeValueFormatter)
-	yTicks := generateTicks(yRange, true, intValueFormatter)
+	xRange, yRange, secondaryRange := c.getRanges(canvas, ranged...)
+
+	xTicks := c.XAxis.ticks(xRange)
+	yTicks := generateStepTicks(yRange, true, c.YAxis.formatter)
 
 	axesOuterBox := canvas.Clone().
 		Grow(c.XAxis.Measure(canvas, xRange, xTicks)).
 		Grow(c.YAxis.Measure(canvas, yRange, yTicks))
 
+	var secondaryTicks []Tick
+	if secondaryRange != nil {
+		secondaryTicks = generateStepTicks(secondaryRange, true, c.SecondaryYAxis.formatter)
+		axesOuterBox = axesOuterBox.Grow(c.SecondaryYAxis.Measure(canvas, secondaryRange, secondaryTicks))
+	}
+
 	plot := canvas.OuterConstrain(c.Box(), axesOuterBox)
 
 	xRange.Domain = plot.Width()
 	yRange.Domain = plot.Height()
+	if secondaryRange != nil {
+		secondaryRange.Domain = plot.Height()
+	}
+
+	plotBackground := svg.Rect().
+		Attr("x", svg.Point(plot.Left)).
+		Attr("y", svg.Point(plot.Top)).
+		Attr("width", svg.Px(plot.Width())).
+		Attr("height", svg.Px(plot.Height())).
+		Attr("class", "plot").
+		Attr("style", styles("fill", c.PlotBackground))
+
+	c.renderSVG(e, c.theme().CornerRadius, func(e *svg.Encoder) {
+		c.renderHeader(e)
+		plotBackground.Render(e)
+		c.YAxis.Grid.renderHorizontal(e, plot, yRange, yTicks)
+		c.XAxis.Grid.renderVertical(e, plot, xRange, xTicks)
+		c.renderPlotClip(e, plot)
+		svg.G().
+			Attr("class", "series-layer").
+			Attr("clip-path", svg.URL(c.elementID("plot-clip"))).
+			RenderChildren(e, func(e *svg.Encoder) {
+				if secondaryRange != nil {
+					c.SecondarySeries.render(e, plot, xRange, secondaryRange, "secondary")
+				}
+				c.Series.Render(e, plot, xRange, yRange)
+				if projection != nil {
+					projection.Render(e, plot, xRange, yRange)
+				}
+			})
+		if projection != nil {
+			projection.RenderLabel(e, plot, xRange, yRange, c.lang())
+		}
+		c.YAxis.Render(e, plot, yRange, yTicks)
+		if secondaryRange != nil {
+			c.SecondaryYAxis.Render(e, plot, secondaryRange, secondaryTicks)
+		}
+		c.XAxis.Render(e, plot, xRange, xTicks)
+		if c.Interactive {
+			c.renderTooltips(e, plot, xRange, yRange)
+		}
+	})
+}
+
+// renderPlotClip defines the clip path keeping the series inside the plot,
+// padded so lines at its edges aren't cut in half.
+func (c *Chart) renderPlotClip(e *svg.Encoder, plot *Box) {
+	pad := int(math.Ceil(max(c.Series.StrokeWidth, MinStrokeWidth)))
+	svg.Defs().RenderChildren(e, func(e *svg.Encoder) {
+		svg.ClipPath(c.elementID("plot-clip")).RenderChildren(e, func(e *svg.Encoder) {
+			svg.Rect().
+				Attr("x", svg.Point(plot.Left-pad)).
+				Attr("y", svg.Point(plot.Top-pad)).
+				Attr("width", svg.Point(plot.Width()+2*pad)).
+				Attr("height", svg.Point(plot.Height()+2*pad)).
+				Render(e)
+		})
+	})
+}
+
+func (c *Chart) renderSparkline(e *svg.Encoder) {
+	canvas := c.Box()
+
+	xRange, yRange, _ := c.getRanges(canvas)
+
+	c.renderSVG(e, min(c.theme().CornerRadius, SparklineBackgroundRadius), func(e *svg.Encoder) {
+		c.Series.Render(e, canvas, xRange, yRange)
+		if c.Interactive {
+			c.renderTooltips(e, canvas, xRange, yRange)
+		}
+	})
+}
+
+func (c *Chart) theme() *Theme {
+	if c.Theme != nil {
+		return c.Theme
+	}
+	return &LightTheme
+}
+
+// text returns the chart texts besides the tick labels, whose glyphs an
+// embedded font needs on top of the ASCII ones.
+func (c *Chart) text() string {
+	texts := []string{c.Title, c.Subtitle, c.Series.Name, c.XAxis.Name, c.YAxis.Name, c.SecondaryYAxis.Name}
+	if c.SecondarySeries != nil {
+		texts = append(texts, c.SecondarySeries.Name)
+	}
+	if card, _ := c.card(); card != nil {
+		texts = append(texts, card.Title, card.Message, card.Hint)
+	}
+	return strings.Join(texts, "")
+}
 
+// renderSVG renders the svg root element, with its accessibility text, style
+// and background, and then the content.
+func (c *Chart) renderSVG(e *svg.Encoder, radius int, content func(e *svg.Encoder)) {
 	background := svg.Rect().
 		Attr("x", svg.Point(0)).
 		Attr("y", svg.Point(0)).
@@ -31,67 +157,119 @@ func (c *Chart) Render(w io.Writer) {
 		Attr("height", svg.Px(c.Height)).
 		Attr("class", "background").
 		Attr("style", styles("fill", c.Background)).
-		Attr("rx", "8")
-
-	cssStyles := c.Styles
-	if cssStyles == "" {
-		cssStyles = LightStyles
-	}
+		Attr("rx", svg.Point(radius))
 
 	style := svg.Style().
 		Attr("type", "text/css").
-		Content(cssStyles)
+		Content(GetFont().FontFace(c.text())).
+		Content(c.theme().CSS())
+	if c.animated() {
+		style.Content(animationCSS)
+	}
 
-	svgElement := svg.SVG().
+	svg.SVG().
 		Attr("width", svg.Px(c.Width)).
 		Attr("height", svg.Px(c.Height)).
-		ContentFunc(func(w io.Writer) {
-			style.Render(w)
-			background.Render(w)
-			c.Series.Render(w, plot, xRange, yRange)
-			c.YAxis.Render(w, plot, yRange, yTicks)
-			c.XAxis.Render(w, plot, xRange, xTicks)
+		Attr("viewBox", svg.ViewBox(0, 0, c.Width, c.Height)).
+		Attr("preserveAspectRatio", "xMidYMid meet").
+		Attr("role", "img").
+		Attr("aria-labelledby", c.elementID("title")).
+		Attr("aria-describedby", c.elementID("desc")).
+		Attr("lang", c.lang()).
+		RenderChildren(e, func(e *svg.Encoder) {
+			c.renderAccessibility(e)
+			style.Render(e)
+			background.Render(e)
+			content(e)
 		})
-
-	svgElement.Render(w)
 }
 
-func (c *Chart) getRanges(canvas *Box) (*Range, *Range) {
-	minX, maxX := math.MaxFloat64, -math.MaxFloat64
-	minY, maxY := math.MaxFloat64, -math.MaxFloat64
+// getRanges returns the ranges fitting the chart series and any extra ones,
+// such as a forecast. The secondary range is only set if there is a
+// secondary series.
+func (c *Chart) getRanges(canvas *Box, extra ...*Series) (xRange, yRange, secondaryRange *Range) {
+	primary := append([]*Series{&c.Series}, extra...)
+	minX, maxX, minY, maxY := valueBounds(primary...)
 
-	seriesLength := c.Series.Len()
-	for index := range seriesLength {
-		vX, vY := c.Series.GetValues(index)
-
-		minX = min(minX, vX)
-		maxX = max(maxX, vX)
+	if c.hasSecondary() {
+		minSX, maxSX, minSY, maxSY := valueBounds(c.SecondarySeries)
+		minX, maxX = min(minX, minSX), max(maxX, maxSX)
+		secondaryRange = c.yRange(canvas, minSY, maxSY)
+	}
 
-		minY = min(minY, vY)
-		maxY = max(maxY, vY)
+	xRange = &Range{
+		Min:    minX,
+		Max:    maxX,
+		Domain: canvas.Width(),
 	}
 
-	delta := maxY - minY
-	roundTo := getRoundToForDelta(delta)
+	return xRange, c.yRange(canvas, minY, maxY), secondaryRange
+}
 
+func (c *Chart) yRange(canvas *Box, minY, maxY float64) *Range {
 	yRange := &Range{
-		Min:    roundDown(minY, roundTo),
-		Max:    roundUp(maxY, roundTo),
+		Min:    minY,
+		Max:    maxY,
 		Domain: canvas.Height(),
 	}
 
-	xRange := &Range{
-		Min:    minX,
-		Max:    maxX,
-		Domain: canvas.Width(),
+	// a flat series, e.g. a range without new stars, is padded so it still
+	// has a range, without going below zero.
+	if yRange.Min == yRange.Max {
+		if yRange.Min >= 1 {
+			yRange.Min--
+		} else {
+			yRange.Max++
+		}
 	}
 
-	return xRange, yRange
+	// sparklines have no axis labels, so they use the whole height
+	if !c.Sparkline {
+		roundTo := getRoundToForDelta(yRange.Max - yRange.Min)
+		yRange.Min = roundDown(yRange.Min, roundTo)
+		yRange.Max = roundUp(yRange.Max, roundTo)
+	}
+
+	return yRange
+}
+
+func valueBounds(series ...*Series) (minX, maxX, minY, maxY float64) {
+	minX, maxX = math.MaxFloat64, -math.MaxFloat64
+	minY, maxY = math.MaxFloat64, -math.MaxFloat64
+
+	for _, s := range series {
+		for index := range s.Len() {
+			vX, vY := s.GetValues(index)
+
+			minX = min(minX, vX)
+			maxX = max(maxX, vX)
+
+			minY = min(minY, vY)
+			maxY = max(maxY, vY)
+		}
+	}
+
+	return
+}
+
+func (c *Chart) hasSecondary() bool {
+	return !c.Sparkline && c.SecondarySeries != nil && c.SecondarySeries.Len() > 0
 }
 
 func (c *Chart) Box() *Box {
+	if c.Sparkline {
+		// keep the stroke inside the canvas
+		inset := int(math.Ceil(max(MinStrokeWidth, c.Series.StrokeWidth)))
+		return &Box{
+			Top:    inset,
+			Left:   inset,
+			Right:  c.Width - inset,
+			Bottom: c.Height - inset,
+		}
+	}
+
 	return &Box{
-		Top:    BoxPadding.Top,
+		Top:    BoxPadding.Top + c.headerHeight(),
 		Left:   BoxPadding.Left,
 		Right:  c.Width - BoxPadding.Right,
 		Bottom: c.Height - BoxPadding.Bottom,
@@ -0,0 +1,100 @@
+package chart
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRender_FlatSeries(t *testing.T) {
+	is := is.New(t)
+	series := Series{
+		XValues: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		YValues: []float64{1, 10},
+	}
+	// a range without new stars.
+	series.Clip(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	var sb strings.Builder
+	is.NoErr((&Chart{Width: 1024, Height: 400, Series: series}).Render(&sb))
+	out := sb.String()
+	is.True(!strings.Contains(out, "NaN"))             // should not have NaN coordinates or labels
+	is.True(!strings.Contains(out, "922337203685477")) // should not overflow coordinates
+	is.True(strings.Contains(out, `>10</text>`))       // should label the flat value
+}
+
+func TestRender_YAxes(t *testing.T) {
+	primary := Series{
+		Name:    "a/a",
+		XValues: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		YValues: []float64{1, 10},
+	}
+	secondary := Series{
+		Name:    "b/b",
+		XValues: []time.Time{time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)},
+		YValues: []float64{1000, 5000},
+	}
+	// axes returns the y axes line x and tick labels, in render order.
+	type axis struct {
+		x      int
+		labels []string
+	}
+	axes := func(t *testing.T, c *Chart) []axis {
+		t.Helper()
+		var sb strings.Builder
+		is.New(t).NoErr(c.Render(&sb))
+
+		var result []axis
+		for _, group := range strings.Split(sb.String(), `<g class="y-axis">`)[1:] {
+			group = group[:strings.Index(group, "</g>")]
+			var a axis
+			line := regexp.MustCompile(`<path [^>]*d="M (\d+) `).FindStringSubmatch(group)
+			a.x, _ = strconv.Atoi(line[1])
+			for _, label := range regexp.MustCompile(`<text [^>]*>([^<]+)</text>`).FindAllStringSubmatch(group, -1) {
+				a.labels = append(a.labels, label[1])
+			}
+			result = append(result, a)
+		}
+		return result
+	}
+
+	t.Run("right", func(t *testing.T) {
+		is := is.New(t)
+		result := axes(t, &Chart{Width: 600, Height: 300, Series: primary})
+		is.Equal(1, len(result))   // should render one axis
+		is.True(result[0].x > 300) // should render the axis on the right
+	})
+
+	t.Run("left", func(t *testing.T) {
+		is := is.New(t)
+		result := axes(t, &Chart{Width: 600, Height: 300, Series: primary, YAxis: YAxis{Position: AxisLeft}})
+		is.Equal(1, len(result))   // should render one axis
+		is.True(result[0].x < 300) // should render the axis on the left
+	})
+
+	t.Run("secondary", func(t *testing.T) {
+		is := is.New(t)
+		result := axes(t, &Chart{
+			Width:           600,
+			Height:          300,
+			Series:          primary,
+			SecondarySeries: &secondary,
+			YAxis:           YAxis{Name: "a/a", Position: AxisLeft},
+			SecondaryYAxis:  YAxis{Name: "b/b", Position: AxisRight},
+		})
+		is.Equal(2, len(result))   // should render both axes
+		is.True(result[0].x < 300) // should render the primary axis on the left
+		is.True(result[1].x > 300) // should render the secondary axis on the right
+		primary, secondary := result[0].labels, result[1].labels
+		is.Equal("1", primary[0])                    // should start the primary range at its minimum
+		is.Equal("10", primary[len(primary)-2])      // should end the primary range at its maximum
+		is.Equal("a/a", primary[len(primary)-1])     // should name the primary axis
+		is.Equal("1k", secondary[0])                 // should start the secondary range at its own minimum
+		is.Equal("5k", secondary[len(secondary)-2])  // should end the secondary range at its own maximum
+		is.Equal("b/b", secondary[len(secondary)-1]) // should name the secondary axis
+	})
+}
@@ -1,17 +1,35 @@
 package chart
 
 import (
-	"io"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/starcharts/internal/chart/svg"
 )
 
 type Series struct {
+	Name        string
 	XValues     []time.Time
 	YValues     []float64
 	StrokeWidth float64
 	Color       string
+
+	// Tolerance simplifies the rendered line, dropping points closer than
+	// this many pixels to it. Zero keeps every point.
+	Tolerance float64
+	// Smooth renders the line as a monotone cubic curve.
+	Smooth bool
+	// Animate makes the line draw itself when the chart is loaded.
+	Animate bool
+
+	// Samples are the times of the actual data points, when the values in
+	// between are interpolated. They are rendered as markers, and the line
+	// is styled as interpolated.
+	Samples []time.Time
+
+	// Total, when set, plots the values as a percentage of it.
+	Total float64
 }
 
 func (ts *Series) Len() int {
@@ -20,44 +38,179 @@ func (ts *Series) Len() int {
 
 func (ts *Series) GetValues(index int) (x, y float64) {
 	x = toFloat64(ts.XValues[index])
-	y = ts.YValues[index]
+	y = ts.plotted(ts.YValues[index])
 	return
 }
 
 func (ts *Series) GetLastValues() (x, y float64) {
 	x = toFloat64(ts.XValues[len(ts.XValues)-1])
-	y = ts.YValues[len(ts.YValues)-1]
+	y = ts.plotted(ts.YValues[len(ts.YValues)-1])
 	return
 }
 
-// Render renders the series.
-func (ts *Series) Render(w io.Writer, canvasBox *Box, xrange, yrange *Range) {
-	if len(ts.XValues) == 0 {
+// plotted returns the value as plotted, which is a percentage of the total
+// if there is one.
+func (ts *Series) plotted(value float64) float64 {
+	if ts.Total > 0 {
+		return value / ts.Total * 100
+	}
+	return value
+}
+
+// ValueAt returns the series value at t, interpolating between the
+// surrounding points. Before the first point the value is zero, and after
+// the last one it is the last value.
+func (ts *Series) ValueAt(t time.Time) float64 {
+	index := sort.Search(ts.Len(), func(i int) bool {
+		return !ts.XValues[i].Before(t)
+	})
+
+	switch {
+	case index == ts.Len():
+		return ts.YValues[ts.Len()-1]
+	case ts.XValues[index].Equal(t):
+		return ts.YValues[index]
+	case index == 0:
+		return 0
+	}
+
+	x0, x1 := ts.XValues[index-1], ts.XValues[index]
+	y0, y1 := ts.YValues[index-1], ts.YValues[index]
+	ratio := float64(t.Sub(x0)) / float64(x1.Sub(x0))
+	return y0 + (y1-y0)*ratio
+}
+
+// Clip restricts the series to the given time range, keeping the cumulative
+// values: points are added at the range bounds with the interpolated value.
+// A zero from or to leaves that side unbounded.
+func (ts *Series) Clip(from, to time.Time) {
+	if ts.Len() == 0 {
 		return
 	}
 
-	cb := canvasBox.Bottom
-	cl := canvasBox.Left
+	var xs []time.Time
+	var ys []float64
+	if !from.IsZero() && from.After(ts.XValues[0]) {
+		xs = append(xs, from)
+		ys = append(ys, ts.ValueAt(from))
+	}
+
+	for i, x := range ts.XValues {
+		if !from.IsZero() && !x.After(from) && len(xs) > 0 {
+			continue
+		}
+		if !to.IsZero() && !x.Before(to) {
+			break
+		}
+		xs = append(xs, x)
+		ys = append(ys, ts.YValues[i])
+	}
+
+	if !to.IsZero() {
+		xs = append(xs, to)
+		ys = append(ys, ts.ValueAt(to))
+	}
+
+	ts.XValues, ts.YValues = xs, ys
+}
+
+// Shift moves the series in time by d.
+func (ts *Series) Shift(d time.Duration) {
+	for i := range ts.XValues {
+		ts.XValues[i] = ts.XValues[i].Add(d)
+	}
+	for i := range ts.Samples {
+		ts.Samples[i] = ts.Samples[i].Add(d)
+	}
+}
+
+// Points returns the series values translated to canvas coordinates.
+func (ts *Series) Points(canvasBox *Box, xrange, yrange *Range) []Point {
+	points := make([]Point, 0, ts.Len())
+	for i := range ts.Len() {
+		vx, vy := ts.GetValues(i)
+		points = append(points, Point{
+			X: canvasBox.Left + xrange.Translate(vx),
+			Y: canvasBox.Bottom - yrange.Translate(vy),
+		})
+	}
+	return points
+}
+
+// Render renders the series.
+func (ts *Series) Render(e *svg.Encoder, canvasBox *Box, xrange, yrange *Range) {
+	ts.render(e, canvasBox, xrange, yrange)
+}
 
-	v0x, v0y := ts.GetValues(0)
-	x0 := cl + xrange.Translate(v0x)
-	y0 := cb - yrange.Translate(v0y)
+// render renders the series line, and its markers if any, adding the given
+// classes to both.
+func (ts *Series) render(e *svg.Encoder, canvasBox *Box, xrange, yrange *Range, classes ...string) {
+	if len(ts.XValues) == 0 {
+		return
+	}
 
-	var vx, vy float64
-	var x, y int
+	lineClasses := append([]string{"series"}, classes...)
+	if len(ts.Samples) > 0 {
+		lineClasses = append(lineClasses, "interpolated")
+	}
+	if ts.Animate {
+		lineClasses = append(lineClasses, "animated")
+	}
 
 	path := svg.Path().
 		Attr("stroke-width", normaliseStrokeWidth(ts.StrokeWidth)).
 		Attr("style", styles("stroke", ts.Color)).
-		Attr("class", "series").
-		MoveTo(x0, y0)
+		Attr("class", strings.Join(lineClasses, " "))
 
-	for i := 1; i < ts.Len(); i++ {
-		vx, vy = ts.GetValues(i)
-		x = cl + xrange.Translate(vx)
-		y = cb - yrange.Translate(vy)
-		path.LineTo(x, y)
+	vertices := ts.vertices(canvasBox, xrange, yrange)
+	if ts.Animate {
+		animate(path, vertices, ts.Smooth)
 	}
+	if ts.Smooth {
+		path.MonotoneCurve(vertices)
+	} else {
+		path.Polyline(vertices)
+	}
+
+	path.Render(e)
+
+	ts.renderMarkers(e, canvasBox, xrange, yrange, classes...)
+}
 
-	path.Render(w)
+// renderMarkers renders a dot at every sample within the series.
+func (ts *Series) renderMarkers(e *svg.Encoder, canvasBox *Box, xrange, yrange *Range, classes ...string) {
+	first, last := ts.XValues[0], ts.XValues[ts.Len()-1]
+	var samples []time.Time
+	for _, t := range ts.Samples {
+		if !t.Before(first) && !t.After(last) {
+			samples = append(samples, t)
+		}
+	}
+	if len(samples) == 0 {
+		return
+	}
+
+	class := strings.Join(append([]string{"marker"}, classes...), " ")
+	radius := svg.Point(MarkerScale * max(ts.StrokeWidth, MinStrokeWidth))
+	svg.G().Attr("class", "markers").RenderChildren(e, func(e *svg.Encoder) {
+		for _, t := range samples {
+			svg.Circle().
+				Attr("class", class).
+				Attr("cx", svg.Point(canvasBox.Left+xrange.Translate(toFloat64(t)))).
+				Attr("cy", svg.Point(canvasBox.Bottom-yrange.Translate(ts.plotted(ts.ValueAt(t))))).
+				Attr("r", radius).
+				Attr("style", styles("fill", ts.Color)).
+				Render(e)
+		}
+	})
+}
+
+// vertices returns the simplified points of the rendered line.
+func (ts *Series) vertices(canvasBox *Box, xrange, yrange *Range) []svg.Vertex {
+	points := ts.Points(canvasBox, xrange, yrange)
+	vertices := make([]svg.Vertex, 0, len(points))
+	for _, p := range points {
+		vertices = append(vertices, svg.Vertex{X: float64(p.X), Y: float64(p.Y)})
+	}
+	return svg.Simplify(vertices, ts.Tolerance)
 }
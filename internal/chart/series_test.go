@@ -0,0 +1,115 @@
+package chart
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/chart/svg"
+	"github.com/matryer/is"
+)
+
+func TestSeriesClip(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2021, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	series := func() *Series {
+		return &Series{
+			XValues: []time.Time{day(1), day(11), day(21), day(31)},
+			YValues: []float64{1, 101, 201, 301},
+		}
+	}
+
+	t.Run("unbounded", func(t *testing.T) {
+		is := is.New(t)
+		s := series()
+		s.Clip(time.Time{}, time.Time{})
+		is.Equal(series(), s) // should keep the series as is
+	})
+
+	t.Run("keeps the cumulative baseline", func(t *testing.T) {
+		is := is.New(t)
+		s := series()
+		s.Clip(day(6), day(26))
+		is.Equal([]time.Time{day(6), day(11), day(21), day(26)}, s.XValues) // should add points at the bounds
+		is.Equal([]float64{51, 101, 201, 251}, s.YValues)                   // should interpolate the bounds values
+	})
+
+	t.Run("after the last point", func(t *testing.T) {
+		is := is.New(t)
+		s := series()
+		s.Clip(day(21), day(40))
+		is.Equal([]time.Time{day(21), day(31), day(40)}, s.XValues) // should extend to the end of the range
+		is.Equal([]float64{201, 301, 301}, s.YValues)               // should keep the last value
+	})
+
+	t.Run("before the first point", func(t *testing.T) {
+		is := is.New(t)
+		s := series()
+		s.Clip(day(1).AddDate(0, -1, 0), day(11))
+		is.Equal([]time.Time{day(1), day(11)}, s.XValues) // should not add a point before the first star
+		is.Equal([]float64{1, 101}, s.YValues)
+	})
+}
+
+func TestSeriesMarkers(t *testing.T) {
+	is := is.New(t)
+	day := func(d int) time.Time {
+		return time.Date(2021, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	s := &Series{
+		XValues: []time.Time{day(11), day(21), day(31)},
+		YValues: []float64{100, 200, 300},
+		Samples: []time.Time{day(1), day(11), day(31)},
+	}
+	box := &Box{Top: 0, Left: 0, Right: 100, Bottom: 100}
+	xr := &Range{Min: toFloat64(day(11)), Max: toFloat64(day(31)), Domain: 100}
+	yr := &Range{Min: 100, Max: 300, Domain: 100}
+
+	var sb strings.Builder
+	s.Render(svg.NewEncoder(&sb), box, xr, yr)
+	out := sb.String()
+	is.True(strings.Contains(out, `class="series interpolated"`)) // should style the line as interpolated
+	is.Equal(2, strings.Count(out, "<circle"))                    // should skip samples outside the series
+	is.True(strings.Contains(out, `cx="100" cy="0"`))             // should place the marker on the line
+}
+
+func TestSeriesShift(t *testing.T) {
+	is := is.New(t)
+	day := func(d int) time.Time {
+		return time.Date(2021, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	// a gap of a month between the second and third stars.
+	s := &Series{
+		XValues: []time.Time{day(1), day(2), day(33)},
+		YValues: []float64{1, 2, 3},
+		Samples: []time.Time{day(2)},
+	}
+	s.Shift(-24 * time.Hour)
+	is.Equal([]time.Time{day(0), day(1), day(32)}, s.XValues) // should shift every point, keeping the gap
+	is.Equal([]float64{1, 2, 3}, s.YValues)                   // should keep the values
+	is.Equal([]time.Time{day(1)}, s.Samples)                  // should shift the samples
+}
+
+func TestSeriesPercent(t *testing.T) {
+	is := is.New(t)
+	day := func(d int) time.Time {
+		return time.Date(2021, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	s := Series{
+		XValues: []time.Time{day(1), day(11), day(21)},
+		YValues: []float64{0, 50, 200},
+		Total:   200,
+	}
+	for i, expected := range []float64{0, 25, 100} {
+		_, y := s.GetValues(i)
+		is.Equal(expected, y) // should plot a percentage of the total
+	}
+
+	var sb strings.Builder
+	is.NoErr((&Chart{Width: 1024, Height: 400, Series: s, YAxis: YAxis{ValueFormatter: PercentValueFormatter}}).Render(&sb))
+	out := sb.String()
+	is.True(!strings.Contains(out, "NaN"))         // should not divide the zero first value
+	is.True(strings.Contains(out, ">0%</text>"))   // should start the axis at zero
+	is.True(strings.Contains(out, ">100%</text>")) // should end the axis at the total
+}
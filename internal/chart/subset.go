@@ -0,0 +1,212 @@
+package chart
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// glyf composite glyph flags.
+const (
+	argsAreWords   = 0x0001
+	haveScale      = 0x0008
+	moreComponents = 0x0020
+	haveXAndYScale = 0x0040
+	haveTwoByTwo   = 0x0080
+)
+
+const (
+	// tableRecordSize is the size of a table directory record.
+	tableRecordSize = 16
+	// checksumMagic minus the font checksum is the head checksum adjustment.
+	checksumMagic = 0xB1B0AFBA
+)
+
+// droppedTables are left out of subsets: the signature no longer matches,
+// and substitutions, e.g. ligatures, would use glyphs left out.
+var droppedTables = map[string]bool{"DSIG": true, "GSUB": true}
+
+// subsetTrueType returns the TrueType font with only the outlines of the
+// given glyphs, the glyphs they are composed of and .notdef. Glyph ids are
+// kept, so the other tables still apply, and the glyphs left out are empty.
+func subsetTrueType(data []byte, glyphs []uint16) ([]byte, error) {
+	tables, err := readTables(data)
+	if err != nil {
+		return nil, err
+	}
+	head, loca, glyf, maxp := tables["head"], tables["loca"], tables["glyf"], tables["maxp"]
+	if len(head) < 54 || len(maxp) < 6 || loca == nil || glyf == nil {
+		return nil, errors.New("not a TrueType font")
+	}
+
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+	offsets, err := readLoca(loca, numGlyphs, int16(binary.BigEndian.Uint16(head[50:])) == 1, len(glyf))
+	if err != nil {
+		return nil, err
+	}
+
+	keep := make([]bool, numGlyphs)
+	queue := append([]uint16{0}, glyphs...)
+	for len(queue) > 0 {
+		g := queue[0]
+		queue = queue[1:]
+		if int(g) >= numGlyphs || keep[g] {
+			continue
+		}
+		keep[g] = true
+		queue = append(queue, components(glyf[offsets[g]:offsets[g+1]])...)
+	}
+
+	// glyphs are copied padded to 4 bytes, indexed by a long loca.
+	var newGlyf []byte
+	newLoca := make([]byte, 4*(numGlyphs+1))
+	for g := range numGlyphs {
+		binary.BigEndian.PutUint32(newLoca[4*g:], uint32(len(newGlyf)))
+		if keep[g] {
+			newGlyf = append(newGlyf, pad(glyf[offsets[g]:offsets[g+1]])...)
+		}
+	}
+	binary.BigEndian.PutUint32(newLoca[4*numGlyphs:], uint32(len(newGlyf)))
+
+	newHead := append([]byte(nil), head...)
+	binary.BigEndian.PutUint32(newHead[8:], 0)
+	binary.BigEndian.PutUint16(newHead[50:], 1)
+
+	tables["glyf"], tables["loca"], tables["head"] = newGlyf, newLoca, newHead
+	for tag := range droppedTables {
+		delete(tables, tag)
+	}
+	return writeTables(data[:4], tables), nil
+}
+
+func readTables(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, errors.New("font is too short")
+	}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	if len(data) < 12+numTables*tableRecordSize {
+		return nil, errors.New("font is too short")
+	}
+
+	tables := map[string][]byte{}
+	for i := range numTables {
+		record := data[12+i*tableRecordSize:]
+		tag := string(record[:4])
+		offset := int(binary.BigEndian.Uint32(record[8:]))
+		length := int(binary.BigEndian.Uint32(record[12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, fmt.Errorf("table %s is out of bounds", tag)
+		}
+		tables[tag] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+func readLoca(loca []byte, numGlyphs int, long bool, glyfLength int) ([]int, error) {
+	size := 2
+	if long {
+		size = 4
+	}
+	if len(loca) < size*(numGlyphs+1) {
+		return nil, errors.New("loca table is too short")
+	}
+
+	offsets := make([]int, numGlyphs+1)
+	for i := range offsets {
+		if long {
+			offsets[i] = int(binary.BigEndian.Uint32(loca[4*i:]))
+		} else {
+			offsets[i] = 2 * int(binary.BigEndian.Uint16(loca[2*i:]))
+		}
+		if offsets[i] > glyfLength || (i > 0 && offsets[i] < offsets[i-1]) {
+			return nil, errors.New("loca table is invalid")
+		}
+	}
+	return offsets, nil
+}
+
+// components returns the glyphs a composite glyph is made of.
+func components(glyph []byte) []uint16 {
+	if len(glyph) < 10 || int16(binary.BigEndian.Uint16(glyph)) >= 0 {
+		return nil
+	}
+
+	var result []uint16
+	for i := 10; i+4 <= len(glyph); {
+		flags := binary.BigEndian.Uint16(glyph[i:])
+		result = append(result, binary.BigEndian.Uint16(glyph[i+2:]))
+		i += 4
+		if flags&argsAreWords != 0 {
+			i += 4
+		} else {
+			i += 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			i += 2
+		case flags&haveXAndYScale != 0:
+			i += 4
+		case flags&haveTwoByTwo != 0:
+			i += 8
+		}
+		if flags&moreComponents == 0 {
+			break
+		}
+	}
+	return result
+}
+
+func writeTables(version []byte, tables map[string][]byte) []byte {
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	numTables := len(tags)
+	entrySelector := bits.Len(uint(numTables)) - 1
+	searchRange := (1 << entrySelector) * tableRecordSize
+
+	out := make([]byte, 12+numTables*tableRecordSize)
+	copy(out, version)
+	binary.BigEndian.PutUint16(out[4:], uint16(numTables))
+	binary.BigEndian.PutUint16(out[6:], uint16(searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(numTables*tableRecordSize-searchRange))
+
+	var headOffset int
+	for i, tag := range tags {
+		table := tables[tag]
+		record := out[12+i*tableRecordSize:]
+		copy(record, tag)
+		binary.BigEndian.PutUint32(record[4:], checksum(table))
+		binary.BigEndian.PutUint32(record[8:], uint32(len(out)))
+		binary.BigEndian.PutUint32(record[12:], uint32(len(table)))
+		if tag == "head" {
+			headOffset = len(out)
+		}
+		out = append(out, pad(table)...)
+	}
+
+	binary.BigEndian.PutUint32(out[headOffset+8:], checksumMagic-checksum(out))
+	return out
+}
+
+func checksum(data []byte) uint32 {
+	var sum uint32
+	data = pad(data)
+	for i := 0; i < len(data); i += 4 {
+		sum += binary.BigEndian.Uint32(data[i:])
+	}
+	return sum
+}
+
+// pad pads data with zeros to a multiple of 4 bytes.
+func pad(data []byte) []byte {
+	if len(data)%4 == 0 {
+		return data
+	}
+	return append(append([]byte(nil), data...), make([]byte, 4-len(data)%4)...)
+}
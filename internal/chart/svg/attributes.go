@@ -0,0 +1,42 @@
+package svg
+
+import "strings"
+
+type attribute struct {
+	key, value string
+}
+
+// attributes keep the order they were first set in, so the same chart always
+// renders to the same bytes.
+type attributes []attribute
+
+func (a *attributes) set(key, value string) {
+	for i, attr := range *a {
+		if attr.key != key {
+			continue
+		}
+		if value == "" {
+			*a = append((*a)[:i], (*a)[i+1:]...)
+			return
+		}
+		(*a)[i].value = value
+		return
+	}
+	if value != "" {
+		*a = append(*a, attribute{key, value})
+	}
+}
+
+func (a attributes) String() string {
+	var sb strings.Builder
+	for i, attr := range a {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(attr.key)
+		sb.WriteString(`="`)
+		sb.WriteString(EscapeAttr(attr.value))
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
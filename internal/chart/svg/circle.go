@@ -0,0 +1,5 @@
+package svg
+
+func Circle() *TagBuilder {
+	return &TagBuilder{tag: "circle"}
+}
@@ -0,0 +1,9 @@
+package svg
+
+func Title() *TagBuilder {
+	return &TagBuilder{tag: "title"}
+}
+
+func Desc() *TagBuilder {
+	return &TagBuilder{tag: "desc"}
+}
@@ -0,0 +1,44 @@
+package svg
+
+import "io"
+
+// Encoder writes elements straight to the underlying writer, without
+// buffering them. After the first write error every other write is skipped,
+// and the error is returned by Err.
+type Encoder struct {
+	w   io.Writer
+	err error
+}
+
+// NewEncoder returns an encoder writing to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Err returns the first error writing to the underlying writer.
+func (e *Encoder) Err() error {
+	return e.err
+}
+
+func (e *Encoder) write(s string) {
+	if e.err != nil || s == "" {
+		return
+	}
+	_, e.err = io.WriteString(e.w, s)
+}
+
+func (e *Encoder) openTag(tag string, attrs attributes, selfClosing bool) {
+	e.write("<" + tag)
+	if len(attrs) > 0 {
+		e.write(" " + attrs.String())
+	}
+	if selfClosing {
+		e.write(" />")
+		return
+	}
+	e.write(">")
+}
+
+func (e *Encoder) closeTag(tag string) {
+	e.write("</" + tag + ">")
+}
@@ -0,0 +1,43 @@
+package svg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("broken pipe")
+}
+
+func TestEncoder(t *testing.T) {
+	t.Run("nested elements", func(t *testing.T) {
+		is := is.New(t)
+		var sb strings.Builder
+		e := NewEncoder(&sb)
+		SVG().RenderChildren(e, func(e *Encoder) {
+			Rect().Attr("x", "1").Render(e)
+			Text().Content("a").Render(e)
+		})
+		is.NoErr(e.Err())
+		is.Equal(`<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"><rect x="1" /><text>a</text></svg>`, sb.String())
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		is := is.New(t)
+		w := &failingWriter{}
+		e := NewEncoder(w)
+		SVG().RenderChildren(e, func(e *Encoder) {
+			Rect().Attr("x", "1").Render(e)
+		})
+		is.Equal("broken pipe", e.Err().Error()) // should return the write error
+		is.Equal(1, w.writes)                    // should not write after failing
+	})
+}
@@ -0,0 +1,18 @@
+package svg
+
+import "strings"
+
+var (
+	textEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attributeEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// EscapeText escapes s to be used as the text content of an element.
+func EscapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+// EscapeAttr escapes s to be used as a double-quoted attribute value.
+func EscapeAttr(s string) string {
+	return attributeEscaper.Replace(s)
+}
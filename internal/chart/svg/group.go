@@ -0,0 +1,23 @@
+package svg
+
+// G groups elements, so they share attributes such as a transform, a class or
+// a clip path.
+func G() *TagBuilder {
+	return &TagBuilder{tag: "g"}
+}
+
+// Defs holds elements that are only rendered when referenced, such as clip
+// paths.
+func Defs() *TagBuilder {
+	return &TagBuilder{tag: "defs"}
+}
+
+// ClipPath clips the elements referencing it by id to its children.
+func ClipPath(id string) *TagBuilder {
+	return (&TagBuilder{tag: "clipPath"}).Attr("id", id)
+}
+
+// URL references the element with the given id, e.g. in a clip-path.
+func URL(id string) string {
+	return "url(#" + id + ")"
+}
@@ -13,3 +13,7 @@ func Px[T Number](value T) string {
 func Point[T Number](value T) string {
 	return fmt.Sprintf("%v", value)
 }
+
+func ViewBox[T Number](minX, minY, width, height T) string {
+	return fmt.Sprintf("%v %v %v %v", minX, minY, width, height)
+}
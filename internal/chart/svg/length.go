@@ -0,0 +1,52 @@
+package svg
+
+import "math"
+
+// curveSteps is how many straight lines approximate each cubic segment when
+// measuring a curve.
+const curveSteps = 16
+
+// Length returns the length of the line Polyline draws through the vertices,
+// or of the curve MonotoneCurve draws if smooth is set.
+func Length(vertices []Vertex, smooth bool) float64 {
+	if !smooth || len(vertices) < 3 {
+		var length float64
+		for i := 1; i < len(vertices); i++ {
+			length += distance(vertices[i-1], vertices[i])
+		}
+		return length
+	}
+
+	var length float64
+	tangents := monotoneTangents(vertices)
+	for i := 1; i < len(vertices); i++ {
+		v0, v1 := vertices[i-1], vertices[i]
+		h := v1.X - v0.X
+		if h <= 0 {
+			length += distance(v0, v1)
+			continue
+		}
+		c1 := Vertex{v0.X + h/3, v0.Y + tangents[i-1]*h/3}
+		c2 := Vertex{v1.X - h/3, v1.Y - tangents[i]*h/3}
+		prev := v0
+		for step := 1; step <= curveSteps; step++ {
+			next := cubicAt(v0, c1, c2, v1, float64(step)/curveSteps)
+			length += distance(prev, next)
+			prev = next
+		}
+	}
+	return length
+}
+
+func cubicAt(p0, p1, p2, p3 Vertex, t float64) Vertex {
+	u := 1 - t
+	a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+	return Vertex{
+		X: a*p0.X + b*p1.X + c*p2.X + d*p3.X,
+		Y: a*p0.Y + b*p1.Y + c*p2.Y + d*p3.Y,
+	}
+}
+
+func distance(a, b Vertex) float64 {
+	return math.Hypot(b.X-a.X, b.Y-a.Y)
+}
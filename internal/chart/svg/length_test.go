@@ -0,0 +1,23 @@
+package svg
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestLength(t *testing.T) {
+	t.Run("polyline", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(10.0, Length([]Vertex{{0, 0}, {3, 4}, {6, 8}}, false)) // should sum the segments
+	})
+
+	t.Run("smooth", func(t *testing.T) {
+		is := is.New(t)
+		vertices := []Vertex{{0, 10}, {5, 8}, {10, 0}}
+		straight := Length(vertices, false)
+		curved := Length(vertices, true)
+		is.True(curved >= straight)    // a curve is never shorter than its chords
+		is.True(curved < straight*1.1) // but it should be close to them
+	})
+}
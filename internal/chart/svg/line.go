@@ -0,0 +1,5 @@
+package svg
+
+func Line() *TagBuilder {
+	return &TagBuilder{tag: "line"}
+}
@@ -2,7 +2,6 @@ package svg
 
 import (
 	"fmt"
-	"io"
 	"math"
 	"strings"
 )
@@ -13,17 +12,13 @@ type PathBuilder struct {
 }
 
 func (pb *PathBuilder) Attr(key, value string) *PathBuilder {
-	if value == "" {
-		delete(pb.attributes, key)
-		return pb
-	}
-	pb.attributes[key] = value
+	pb.attributes.set(key, value)
 
 	return pb
 }
 
 func (pb *PathBuilder) Content(content string) *PathBuilder {
-	pb.content.WriteString(content)
+	pb.TagBuilder.Content(content)
 
 	return pb
 }
@@ -52,6 +47,51 @@ func (pb *PathBuilder) LineToF(x, y float64) *PathBuilder {
 	return pb
 }
 
+func (pb *PathBuilder) CubicTo(x1, y1, x2, y2, x, y float64) *PathBuilder {
+	pb.path = append(pb.path, "C "+num(x1)+" "+num(y1)+" "+num(x2)+" "+num(y2)+" "+num(x)+" "+num(y))
+
+	return pb
+}
+
+// Polyline adds straight lines through the given vertices.
+func (pb *PathBuilder) Polyline(vertices []Vertex) *PathBuilder {
+	for i, v := range vertices {
+		if i == 0 {
+			pb.path = append(pb.path, "M "+num(v.X)+" "+num(v.Y))
+			continue
+		}
+		pb.path = append(pb.path, "L "+num(v.X)+" "+num(v.Y))
+	}
+
+	return pb
+}
+
+// MonotoneCurve adds a smooth curve through the given vertices, which must be
+// sorted by X. The curve never overshoots, so it keeps the series monotonicity.
+func (pb *PathBuilder) MonotoneCurve(vertices []Vertex) *PathBuilder {
+	if len(vertices) < 3 {
+		return pb.Polyline(vertices)
+	}
+
+	tangents := monotoneTangents(vertices)
+	pb.path = append(pb.path, "M "+num(vertices[0].X)+" "+num(vertices[0].Y))
+	for i := 1; i < len(vertices); i++ {
+		v0, v1 := vertices[i-1], vertices[i]
+		h := v1.X - v0.X
+		if h <= 0 {
+			pb.path = append(pb.path, "L "+num(v1.X)+" "+num(v1.Y))
+			continue
+		}
+		pb.CubicTo(
+			v0.X+h/3, v0.Y+tangents[i-1]*h/3,
+			v1.X-h/3, v1.Y-tangents[i]*h/3,
+			v1.X, v1.Y,
+		)
+	}
+
+	return pb
+}
+
 func (pb *PathBuilder) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) *PathBuilder {
 	startAngle = RadianAdd(startAngle, _pi2)
 	endAngle := RadianAdd(startAngle, delta)
@@ -80,16 +120,15 @@ func (pb *PathBuilder) ArcTo(cx, cy int, rx, ry, startAngle, delta float64) *Pat
 	return pb
 }
 
-func (pb *PathBuilder) Render(io io.Writer) {
-	pb.attributes["d"] = strings.Join(pb.path, " ")
-	pb.TagBuilder.Render(io)
+func (pb *PathBuilder) Render(e *Encoder) {
+	pb.attributes.set("d", strings.Join(pb.path, " "))
+	pb.TagBuilder.Render(e)
 }
 
 func Path() *PathBuilder {
 	return &PathBuilder{
 		TagBuilder: TagBuilder{
-			tag:        "path",
-			attributes: map[string]string{},
+			tag: "path",
 		},
 		path: []string{},
 	}
@@ -97,6 +136,6 @@ func Path() *PathBuilder {
 
 func (pb *PathBuilder) String() string {
 	builder := &strings.Builder{}
-	pb.Render(builder)
+	pb.Render(NewEncoder(builder))
 	return builder.String()
 }
@@ -0,0 +1,40 @@
+package svg
+
+import "strings"
+
+type PolylineBuilder struct {
+	TagBuilder
+	points []string
+}
+
+func Polyline() *PolylineBuilder {
+	return &PolylineBuilder{
+		TagBuilder: TagBuilder{tag: "polyline"},
+	}
+}
+
+func (pb *PolylineBuilder) Attr(key, value string) *PolylineBuilder {
+	pb.attributes.set(key, value)
+
+	return pb
+}
+
+// Points adds the vertices to the line.
+func (pb *PolylineBuilder) Points(vertices ...Vertex) *PolylineBuilder {
+	for _, v := range vertices {
+		pb.points = append(pb.points, num(v.X)+","+num(v.Y))
+	}
+
+	return pb
+}
+
+func (pb *PolylineBuilder) Render(e *Encoder) {
+	pb.attributes.set("points", strings.Join(pb.points, " "))
+	pb.TagBuilder.Render(e)
+}
+
+func (pb *PolylineBuilder) String() string {
+	builder := &strings.Builder{}
+	pb.Render(NewEncoder(builder))
+	return builder.String()
+}
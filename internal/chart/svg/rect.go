@@ -1,5 +1,5 @@
 package svg
 
 func Rect() *TagBuilder {
-	return &TagBuilder{tag: "rect", attributes: map[string]string{}}
+	return &TagBuilder{tag: "rect"}
 }
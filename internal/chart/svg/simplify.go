@@ -0,0 +1,92 @@
+package svg
+
+import (
+	"math"
+	"strconv"
+)
+
+// Vertex is a point in the SVG coordinate space.
+type Vertex struct {
+	X, Y float64
+}
+
+// Simplify reduces the number of vertices of a polyline using the
+// Ramer-Douglas-Peucker algorithm, dropping every vertex closer than
+// tolerance to the simplified line.
+func Simplify(vertices []Vertex, tolerance float64) []Vertex {
+	if len(vertices) < 3 || tolerance <= 0 {
+		return vertices
+	}
+
+	keep := make([]bool, len(vertices))
+	keep[0], keep[len(vertices)-1] = true, true
+
+	// iterative, huge series would otherwise recurse too deep
+	stack := [][2]int{{0, len(vertices) - 1}}
+	for len(stack) > 0 {
+		first, last := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		index, distance := -1, tolerance
+		for i := first + 1; i < last; i++ {
+			if d := segmentDistance(vertices[i], vertices[first], vertices[last]); d > distance {
+				index, distance = i, d
+			}
+		}
+
+		if index >= 0 {
+			keep[index] = true
+			stack = append(stack, [2]int{first, index}, [2]int{index, last})
+		}
+	}
+
+	result := make([]Vertex, 0, len(vertices))
+	for i, v := range vertices {
+		if keep[i] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// segmentDistance is the distance between p and the segment from a to b.
+func segmentDistance(p, a, b Vertex) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// monotoneTangents computes the tangents of a monotone cubic interpolation
+// (Fritsch-Carlson) through the given vertices, which must be sorted by X.
+func monotoneTangents(vertices []Vertex) []float64 {
+	n := len(vertices)
+	slopes := make([]float64, n-1)
+	for i := range n - 1 {
+		if h := vertices[i+1].X - vertices[i].X; h > 0 {
+			slopes[i] = (vertices[i+1].Y - vertices[i].Y) / h
+		}
+	}
+
+	tangents := make([]float64, n)
+	tangents[0], tangents[n-1] = slopes[0], slopes[n-2]
+	for i := 1; i < n-1; i++ {
+		d0, d1 := slopes[i-1], slopes[i]
+		if d0*d1 <= 0 {
+			continue
+		}
+		h0 := vertices[i].X - vertices[i-1].X
+		h1 := vertices[i+1].X - vertices[i].X
+		tangents[i] = 3 * (h0 + h1) / ((2*h1+h0)/d0 + (h1+2*h0)/d1)
+	}
+	return tangents
+}
+
+// num formats a coordinate with at most two decimal places.
+func num(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,54 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestSimplify(t *testing.T) {
+	t.Run("drops collinear and close vertices", func(t *testing.T) {
+		is := is.New(t)
+		vertices := []Vertex{{0, 0}, {1, 1}, {2, 2}, {3, 2.2}, {4, 2}, {5, 5}}
+		is.Equal([]Vertex{{0, 0}, {2, 2}, {4, 2}, {5, 5}}, Simplify(vertices, 0.5)) // should keep only the corners
+	})
+
+	t.Run("zero tolerance keeps everything", func(t *testing.T) {
+		is := is.New(t)
+		vertices := []Vertex{{0, 0}, {1, 1}, {2, 2}}
+		is.Equal(vertices, Simplify(vertices, 0)) // should keep every vertex
+	})
+
+	t.Run("huge series", func(t *testing.T) {
+		is := is.New(t)
+		var vertices []Vertex
+		for i := range 100_000 {
+			vertices = append(vertices, Vertex{X: float64(i / 100), Y: float64(i / 1000)})
+		}
+		is.True(len(Simplify(vertices, 0.5)) < 300) // should drop most vertices
+	})
+}
+
+func TestMonotoneCurve(t *testing.T) {
+	t.Run("cubic commands", func(t *testing.T) {
+		is := is.New(t)
+		d := Path().MonotoneCurve([]Vertex{{0, 10}, {3, 7}, {6, 1}}).path
+		is.Equal("M 0 10", d[0])                  // should start with a move
+		is.True(strings.HasPrefix(d[1], "C 1 9")) // should use cubic commands
+		is.Equal(3, len(d))                       // should have one command per vertex
+	})
+
+	t.Run("flat segments stay flat", func(t *testing.T) {
+		is := is.New(t)
+		tangents := monotoneTangents([]Vertex{{0, 10}, {1, 5}, {2, 5}, {3, 0}})
+		is.Equal(0.0, tangents[1]) // should not overshoot before a plateau
+		is.Equal(0.0, tangents[2]) // should not overshoot after a plateau
+	})
+
+	t.Run("falls back to lines", func(t *testing.T) {
+		is := is.New(t)
+		d := Path().MonotoneCurve([]Vertex{{0, 10}, {3, 7}}).path
+		is.Equal([]string{"M 0 10", "L 3 7"}, d) // should use lines for two vertices
+	})
+}
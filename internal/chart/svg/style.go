@@ -6,9 +6,6 @@ type StyleBuilder struct {
 
 func Style() *StyleBuilder {
 	return &StyleBuilder{
-		TagBuilder{
-			tag:        "style",
-			attributes: map[string]string{},
-		},
+		TagBuilder{tag: "style"},
 	}
 }
@@ -1,68 +1,50 @@
 package svg
 
-import (
-	"fmt"
-	"io"
-	"strings"
-)
+import "strings"
 
 type TagBuilder struct {
 	tag        string
-	attributes map[string]string
+	attributes attributes
 	content    strings.Builder
 }
 
-func (t *TagBuilder) Write(p []byte) (n int, err error) {
-	return t.content.Write(p)
-}
-
-func (t *TagBuilder) Render(io io.Writer) {
+// Render writes the element to e.
+func (t *TagBuilder) Render(e *Encoder) {
 	if t.content.Len() == 0 {
-		_, err := fmt.Fprintf(io, "<%s %s />", t.tag, t.attrString())
-		if err != nil {
-			panic(err)
-		}
-	} else {
-		_, err := fmt.Fprintf(io, "<%s %s>%s</%s>", t.tag, t.attrString(), t.content.String(), t.tag)
-		if err != nil {
-			panic(err)
-		}
+		e.openTag(t.tag, t.attributes, true)
+		return
 	}
+	e.openTag(t.tag, t.attributes, false)
+	e.write(t.content.String())
+	e.closeTag(t.tag)
 }
 
-func (t *TagBuilder) Attr(key, value string) *TagBuilder {
-	if value == "" {
-		delete(t.attributes, key)
-		return t
-	}
-	t.attributes[key] = value
-	return t
+// RenderChildren writes the element to e, with everything children writes
+// nested in it.
+func (t *TagBuilder) RenderChildren(e *Encoder, children func(e *Encoder)) {
+	e.openTag(t.tag, t.attributes, false)
+	e.write(t.content.String())
+	children(e)
+	e.closeTag(t.tag)
 }
 
-func (t *TagBuilder) Content(content string) *TagBuilder {
-	t.content.WriteString(content)
+// Attr sets the attribute key, or removes it if value is empty. The value is
+// escaped when rendered.
+func (t *TagBuilder) Attr(key, value string) *TagBuilder {
+	t.attributes.set(key, value)
 	return t
 }
 
-func (t *TagBuilder) ContentFunc(contentFunc func(writer io.Writer)) *TagBuilder {
-	contentFunc(&t.content)
-
+// Content appends text to the element, escaping it.
+func (t *TagBuilder) Content(content string) *TagBuilder {
+	t.content.WriteString(EscapeText(content))
 	return t
 }
 
 func (t *TagBuilder) String() string {
 	builder := strings.Builder{}
 
-	t.Render(&builder)
+	t.Render(NewEncoder(&builder))
 
 	return builder.String()
 }
-
-func (t *TagBuilder) attrString() string {
-	var attrs []string
-	for key, value := range t.attributes {
-		attrs = append(attrs, fmt.Sprintf(`%s="%s"`, key, value))
-	}
-
-	return strings.Join(attrs, " ")
-}
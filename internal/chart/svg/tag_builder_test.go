@@ -0,0 +1,56 @@
+package svg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestTagBuilderAttributeOrder(t *testing.T) {
+	is := is.New(t)
+	tag := Rect().
+		Attr("x", "1").
+		Attr("y", "2").
+		Attr("width", "3").
+		Attr("x", "4").
+		Attr("y", "")
+	for range 10 {
+		is.Equal(`<rect x="4" width="3" />`, tag.String()) // should keep the order attributes were set in
+	}
+}
+
+func TestTagBuilderEscaping(t *testing.T) {
+	is := is.New(t)
+	tag := Text().
+		Attr("class", `a"b`).
+		Content("<script> & co")
+	is.Equal(`<text class="a&quot;b">&lt;script&gt; &amp; co</text>`, tag.String())
+}
+
+func TestPrimitives(t *testing.T) {
+	is := is.New(t)
+	var sb strings.Builder
+	e := NewEncoder(&sb)
+	G().
+		Attr("transform", Transform(Translate(10, 20), Rotate(90, 0, 0))).
+		Attr("clip-path", URL("clip")).
+		RenderChildren(e, func(e *Encoder) {
+			Polyline().Points(Vertex{0, 0}, Vertex{1.5, 2}).Render(e)
+			Circle().Attr("r", "2").Render(e)
+		})
+	is.NoErr(e.Err())
+	is.Equal(`<g transform="translate(10,20) rotate(90.00,0,0)" clip-path="url(#clip)"><polyline points="0,0 1.5,2" /><circle r="2" /></g>`, sb.String())
+}
+
+func TestTSpan(t *testing.T) {
+	is := is.New(t)
+	var sb strings.Builder
+	e := NewEncoder(&sb)
+	Text().RenderChildren(e, func(e *Encoder) {
+		TSpan().Content("1.2k").Render(e)
+		TSpan().Attr("class", "muted").Content(" stars").Render(e)
+	})
+	is.NoErr(e.Err())
+	is.Equal(`<text><tspan>1.2k</tspan><tspan class="muted"> stars</tspan></text>`, sb.String()) // should nest the spans in the text
+}
@@ -1,8 +1,10 @@
 package svg
 
 func Text() *TagBuilder {
-	return &TagBuilder{
-		tag: "text",
-		attributes: map[string]string{},
-	}
+	return &TagBuilder{tag: "text"}
+}
+
+// TSpan styles a part of a text, it must be rendered as a child of one.
+func TSpan() *TagBuilder {
+	return &TagBuilder{tag: "tspan"}
 }
@@ -0,0 +1,19 @@
+package svg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Transform joins transforms, which are applied right to left.
+func Transform(transforms ...string) string {
+	return strings.Join(transforms, " ")
+}
+
+func Translate[T Number](x, y T) string {
+	return fmt.Sprintf("translate(%v,%v)", x, y)
+}
+
+func Rotate[T Number](angle float64, cx, cy T) string {
+	return fmt.Sprintf("rotate(%0.2f,%v,%v)", angle, cx, cy)
+}
@@ -0,0 +1,267 @@
+package chart
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Palette are the colors used by a theme. Any CSS color is accepted.
+type Palette struct {
+	Background string `json:"background"`
+	Plot       string `json:"plot"`
+	Text       string `json:"text"`
+	Axis       string `json:"axis"`
+	Series     string `json:"series"`
+	Secondary  string `json:"secondary"`
+	Grid       string `json:"grid"`
+	GridMinor  string `json:"grid_minor"`
+	Hover      string `json:"hover"`
+	Error      string `json:"error"`
+}
+
+// Theme is the look of a chart.
+type Theme struct {
+	Name   string  `json:"name"`
+	Colors Palette `json:"colors"`
+	// DarkColors, when set, are used when the viewer prefers a dark color
+	// scheme.
+	DarkColors *Palette `json:"dark_colors,omitempty"`
+	// FontFamily is the CSS font family, defaults to the font from GetFont.
+	// Text is always measured with the font from GetFont.
+	FontFamily      string  `json:"font_family"`
+	StrokeWidth     float64 `json:"stroke_width"`
+	AxisStrokeWidth float64 `json:"axis_stroke_width"`
+	CornerRadius    int     `json:"corner_radius"`
+}
+
+var lightPalette = Palette{
+	Background: "rgb(255,255,255)",
+	Plot:       "none",
+	Text:       "rgba(51,51,51,1.0)",
+	Axis:       "rgb(51,51,51)",
+	Series:     "#6b63ff",
+	Secondary:  "#e5704b",
+	Grid:       "rgba(51,51,51,0.15)",
+	GridMinor:  "rgba(51,51,51,0.07)",
+	Hover:      "rgba(107,99,255,0.15)",
+	Error:      "#cf222e",
+}
+
+var darkPalette = Palette{
+	Background: "rgb(0,0,0)",
+	Plot:       "none",
+	Text:       "rgb(230,237,243)",
+	Axis:       "rgb(230,237,243)",
+	Series:     "#6b63ff",
+	Secondary:  "#f0885f",
+	Grid:       "rgba(230,237,243,0.2)",
+	GridMinor:  "rgba(230,237,243,0.1)",
+	Hover:      "rgba(107,99,255,0.25)",
+	Error:      "#ff7b72",
+}
+
+var adaptiveDarkPalette = darkPalette.merge(Palette{
+	Background: "none",
+})
+
+var LightTheme = Theme{
+	Name:            "light",
+	Colors:          lightPalette,
+	StrokeWidth:     2,
+	AxisStrokeWidth: 2,
+	CornerRadius:    BackgroundRadius,
+}
+
+var DarkTheme = Theme{
+	Name:            "dark",
+	Colors:          darkPalette,
+	StrokeWidth:     2,
+	AxisStrokeWidth: 2,
+	CornerRadius:    BackgroundRadius,
+}
+
+var AdaptiveTheme = Theme{
+	Name: "adaptive",
+	Colors: lightPalette.merge(Palette{
+		Background: "none",
+	}),
+	DarkColors:      &adaptiveDarkPalette,
+	StrokeWidth:     2,
+	AxisStrokeWidth: 2,
+	CornerRadius:    BackgroundRadius,
+}
+
+// merge returns the palette with the colors set in other.
+func (p Palette) merge(other Palette) Palette {
+	for _, pair := range []struct{ dst, src *string }{
+		{&p.Background, &other.Background},
+		{&p.Plot, &other.Plot},
+		{&p.Text, &other.Text},
+		{&p.Axis, &other.Axis},
+		{&p.Series, &other.Series},
+		{&p.Secondary, &other.Secondary},
+		{&p.Grid, &other.Grid},
+		{&p.GridMinor, &other.GridMinor},
+		{&p.Hover, &other.Hover},
+		{&p.Error, &other.Error},
+	} {
+		if *pair.src != "" {
+			*pair.dst = *pair.src
+		}
+	}
+	return p
+}
+
+func (p Palette) css(indent string) string {
+	var sb strings.Builder
+	for _, rule := range []string{
+		"path { stroke: " + p.Axis + "; }",
+		"path.series { stroke: " + p.Series + "; }",
+		"path.series.secondary { stroke: " + p.Secondary + "; }",
+		"circle.marker { fill: " + p.Series + "; }",
+		"circle.marker.secondary { fill: " + p.Secondary + "; }",
+		"path.forecast { stroke: " + p.Series + "; }",
+		"rect.background { fill: " + p.Background + "; }",
+		"rect.plot { fill: " + p.Plot + "; }",
+		"line.grid-major { stroke: " + p.Grid + "; }",
+		"line.grid-minor { stroke: " + p.GridMinor + "; }",
+		"rect.hit:hover { fill: " + p.Hover + "; }",
+		"text { fill: " + p.Text + "; }",
+		"text.error-title { fill: " + p.Error + "; }",
+	} {
+		sb.WriteString(indent + rule + "\n")
+	}
+	return sb.String()
+}
+
+// CSS returns the theme stylesheet.
+func (t Theme) CSS() string {
+	font := GetFont()
+	family := t.FontFamily
+	if family == "" {
+		family = font.CSSFamily()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`
+path { fill: none; }
+rect.background { stroke: none; }
+rect.plot { stroke: none; }
+line.grid-major { stroke-width: 1; }
+line.grid-minor { stroke-width: 1; stroke-dasharray: 2 2; }
+path.forecast { stroke-dasharray: 6 4; opacity: 0.7; }
+path.series.interpolated { opacity: 0.6; }
+circle.marker { stroke: none; }
+rect.hit { fill: transparent; stroke: none; }
+
+text {
+	stroke-width: 0;
+	stroke: none;
+	font-size: 12.8px;
+	font-family: ` + family + `;
+}
+
+`)
+	sb.WriteString(t.Colors.css(""))
+	if t.DarkColors != nil {
+		sb.WriteString("\n@media (prefers-color-scheme: dark) {\n")
+		sb.WriteString(t.DarkColors.css("\t"))
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// withDefaults fills whatever is not set in the theme from the light theme.
+func (t Theme) withDefaults() Theme {
+	t.Colors = LightTheme.Colors.merge(t.Colors)
+	if t.DarkColors != nil {
+		dark := darkPalette.merge(*t.DarkColors)
+		t.DarkColors = &dark
+	}
+	if t.StrokeWidth <= 0 {
+		t.StrokeWidth = LightTheme.StrokeWidth
+	}
+	if t.AxisStrokeWidth <= 0 {
+		t.AxisStrokeWidth = LightTheme.AxisStrokeWidth
+	}
+	if t.CornerRadius < 0 {
+		t.CornerRadius = 0
+	}
+	return t
+}
+
+// ThemeRegistry holds the themes available by name.
+type ThemeRegistry struct {
+	lock   sync.RWMutex
+	themes map[string]Theme
+}
+
+// Themes is the registry of available themes, with the built-in ones.
+var Themes = NewThemeRegistry()
+
+// NewThemeRegistry returns a registry with the built-in themes.
+func NewThemeRegistry() *ThemeRegistry {
+	return &ThemeRegistry{
+		themes: map[string]Theme{
+			LightTheme.Name:    LightTheme,
+			DarkTheme.Name:     DarkTheme,
+			AdaptiveTheme.Name: AdaptiveTheme,
+		},
+	}
+}
+
+// Register adds or replaces a theme.
+func (r *ThemeRegistry) Register(theme Theme) error {
+	if theme.Name == "" {
+		return fmt.Errorf("theme name is required")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.themes[theme.Name] = theme.withDefaults()
+	return nil
+}
+
+// Get returns the theme with the given name.
+func (r *ThemeRegistry) Get(name string) (Theme, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	theme, ok := r.themes[name]
+	return theme, ok
+}
+
+// Names returns the sorted names of the available themes.
+func (r *ThemeRegistry) Names() []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	var names []string
+	for name := range r.themes {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Load registers the themes from a JSON file containing a list of themes.
+func (r *ThemeRegistry) Load(path string) error {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read themes: %w", err)
+	}
+
+	var themes []Theme
+	if err := json.Unmarshal(bts, &themes); err != nil {
+		return fmt.Errorf("failed to parse themes: %w", err)
+	}
+
+	for _, theme := range themes {
+		if err := r.Register(theme); err != nil {
+			return fmt.Errorf("invalid theme: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package chart
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestThemeRegistry(t *testing.T) {
+	t.Run("built-in themes", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal([]string{"adaptive", "dark", "light"}, NewThemeRegistry().Names()) // should have the built-in themes
+	})
+
+	t.Run("load from file", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "themes.json")
+		is.NoErr(os.WriteFile(path, []byte(`[{
+			"name": "ocean",
+			"colors": {"background": "#0b1d2a", "series": "#2f81f7"},
+			"dark_colors": {"text": "#ffffff"},
+			"corner_radius": 0
+		}]`), 0o600))
+
+		registry := NewThemeRegistry()
+		is.NoErr(registry.Load(path))
+
+		theme, ok := registry.Get("ocean")
+		is.True(ok)                                                    // should register the theme
+		is.Equal("#0b1d2a", theme.Colors.Background)                   // should use the given colors
+		is.Equal(LightTheme.Colors.Text, theme.Colors.Text)            // should default to the light colors
+		is.Equal("#ffffff", theme.DarkColors.Text)                     // should use the given dark colors
+		is.Equal(DarkTheme.Colors.Axis, theme.DarkColors.Axis)         // should default to the dark colors
+		is.Equal(LightTheme.StrokeWidth, theme.StrokeWidth)            // should default the stroke width
+		is.Equal(0, theme.CornerRadius)                                // should allow square corners
+		is.True(strings.Contains(theme.CSS(), "prefers-color-scheme")) // should have dark colors media query
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		is := is.New(t)
+		path := filepath.Join(t.TempDir(), "themes.json")
+		is.NoErr(os.WriteFile(path, []byte(`[{"colors": {}}]`), 0o600))
+		is.True(NewThemeRegistry().Load(path) != nil) // should require a theme name
+	})
+}
@@ -22,11 +22,15 @@ func (t Ticks) String() string {
 }
 
 func generateTicks(rng *Range, isVertical bool, formatter ValueFormatter) []Tick {
-	ticks := []Tick{
-		{Value: rng.Min, Label: formatter(rng.Min)},
-	}
+	return generateStepTicks(rng, isVertical, func(float64) ValueFormatter {
+		return formatter
+	})
+}
 
-	labelBox := measureText(formatter(rng.Min), AxisFontSize)
+// generateStepTicks generates ticks labeled by the formatter for their step,
+// so that close values get distinct labels.
+func generateStepTicks(rng *Range, isVertical bool, formatter func(step float64) ValueFormatter) []Tick {
+	labelBox := measureText(formatter(0)(rng.Min), AxisFontSize)
 
 	var tickSize float64
 	if isVertical {
@@ -44,16 +48,24 @@ func generateTicks(rng *Range, isVertical bool, formatter ValueFormatter) []Tick
 	roundTo := getRoundToForDelta(rangeDelta) / 10
 	intermediateTickCount = min(intermediateTickCount, DefaultTickCountSanityCheck)
 
+	values := []float64{rng.Min}
 	for x := 1; x < intermediateTickCount; x++ {
-		tickValue := rng.Min + roundUp(tickStep*float64(x), roundTo)
+		values = append(values, rng.Min+roundUp(tickStep*float64(x), roundTo))
+	}
+
+	// the last tick is the range max, however close it is to the previous one.
+	step := rangeDelta
+	for i := 1; i < len(values); i++ {
+		step = min(step, values[i]-values[i-1])
+	}
+
+	format := formatter(step)
+	ticks := make([]Tick, 0, len(values)+1)
+	for _, value := range append(values, rng.Max) {
 		ticks = append(ticks, Tick{
-			Value: tickValue,
-			Label: formatter(tickValue),
+			Value: value,
+			Label: format(value),
 		})
 	}
-
-	return append(ticks, Tick{
-		Value: rng.Max,
-		Label: formatter(rng.Max),
-	})
+	return ticks
 }
@@ -0,0 +1,153 @@
+package chart
+
+import (
+	"time"
+)
+
+type timeUnit int
+
+const (
+	day timeUnit = iota
+	week
+	month
+	year
+)
+
+type timeStep struct {
+	unit   timeUnit
+	count  int
+	format string
+}
+
+// timeSteps are the candidate tick intervals, from the finest to the coarsest.
+var timeSteps = []timeStep{
+	{unit: day, count: 1, format: "2006-01-02"},
+	{unit: week, count: 1, format: "2006-01-02"},
+	{unit: week, count: 2, format: "2006-01-02"},
+	{unit: month, count: 1, format: "Jan 2006"},
+	{unit: month, count: 3, format: "Jan 2006"},
+	{unit: month, count: 6, format: "Jan 2006"},
+	{unit: year, count: 1, format: "2006"},
+	{unit: year, count: 2, format: "2006"},
+	{unit: year, count: 5, format: "2006"},
+	{unit: year, count: 10, format: "2006"},
+	{unit: year, count: 25, format: "2006"},
+	{unit: year, count: 50, format: "2006"},
+}
+
+// floor truncates t to the start of the step interval containing it.
+func (s timeStep) floor(t time.Time) time.Time {
+	switch s.unit {
+	case week:
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	case month:
+		m := int(t.Month()) - 1
+		return time.Date(t.Year(), time.Month(m-m%s.count+1), 1, 0, 0, 0, 0, time.UTC)
+	case year:
+		return time.Date(t.Year()-t.Year()%s.count, time.January, 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+func (s timeStep) next(t time.Time) time.Time {
+	switch s.unit {
+	case week:
+		return t.AddDate(0, 0, 7*s.count)
+	case month:
+		return t.AddDate(0, s.count, 0)
+	case year:
+		return t.AddDate(s.count, 0, 0)
+	default:
+		return t.AddDate(0, 0, s.count)
+	}
+}
+
+// boundaries returns the step boundaries within [start, end].
+func (s timeStep) boundaries(start, end time.Time, limit int) []time.Time {
+	var result []time.Time
+	for t := s.floor(start); !t.After(end); t = s.next(t) {
+		if t.Before(start) {
+			continue
+		}
+		if len(result) == limit {
+			return nil
+		}
+		result = append(result, t)
+	}
+	return result
+}
+
+// generateTimeTicks generates X axis ticks snapped to calendar boundaries,
+// picking the finest interval whose labels fit in the range domain.
+func generateTimeTicks(rng *Range) []Tick {
+	start := time.Unix(0, int64(rng.Min)).UTC()
+	end := time.Unix(0, int64(rng.Max)).UTC()
+
+	for _, step := range timeSteps {
+		labelBox := measureText(start.Format(step.format), AxisFontSize)
+		tickSize := labelBox.Width() + MinimumTickHorizontalSpacing
+		maxTicks := min(rng.Domain/tickSize, DefaultTickCountSanityCheck)
+		if maxTicks < 2 {
+			break
+		}
+
+		boundaries := step.boundaries(start, end, maxTicks)
+		if len(boundaries) < 2 {
+			continue
+		}
+
+		ticks := make([]Tick, 0, len(boundaries))
+		for _, t := range boundaries {
+			ticks = append(ticks, Tick{
+				Value: toFloat64(t),
+				Label: t.Format(step.format),
+			})
+		}
+		return ticks
+	}
+
+	return generateTicks(rng, false, TimeValueFormatter)
+}
+
+// elapsedSteps are the candidate tick intervals, in days, for elapsed time
+// axes.
+var elapsedSteps = []int{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000}
+
+// generateElapsedTicks generates X axis ticks labeled with the days elapsed
+// since the origin, at round multiples of days.
+func generateElapsedTicks(rng *Range, origin time.Time) []Tick {
+	first := int(time.Unix(0, int64(rng.Min)).Sub(origin) / dayDuration)
+	last := int(time.Unix(0, int64(rng.Max)).Sub(origin) / dayDuration)
+
+	labelBox := measureText(IntValueFormatter(float64(last)), AxisFontSize)
+	tickSize := labelBox.Width() + MinimumTickHorizontalSpacing
+	maxTicks := min(rng.Domain/tickSize, DefaultTickCountSanityCheck)
+
+	for _, step := range elapsedSteps {
+		start := first - first%step
+		if start < first {
+			start += step
+		}
+		if (last-start)/step+1 > maxTicks {
+			continue
+		}
+
+		var ticks []Tick
+		for days := start; days <= last; days += step {
+			ticks = append(ticks, Tick{
+				Value: toFloat64(origin.Add(time.Duration(days) * dayDuration)),
+				Label: IntValueFormatter(float64(days)),
+			})
+		}
+		if len(ticks) >= 2 {
+			return ticks
+		}
+	}
+
+	return generateTicks(rng, false, func(v any) string {
+		typed, _ := v.(float64)
+		return IntValueFormatter(float64(time.Unix(0, int64(typed)).Sub(origin) / dayDuration))
+	})
+}
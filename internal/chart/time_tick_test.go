@@ -0,0 +1,66 @@
+package chart
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestGenerateTimeTicks(t *testing.T) {
+	for name, tt := range map[string]struct {
+		start, end time.Time
+		domain     int
+		labels     []string
+	}{
+		"years": {
+			start:  time.Date(2014, time.March, 17, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2021, time.August, 2, 0, 0, 0, 0, time.UTC),
+			domain: 400,
+			labels: []string{"2015", "2016", "2017", "2018", "2019", "2020", "2021"},
+		},
+		"quarters": {
+			start:  time.Date(2020, time.February, 10, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2021, time.March, 2, 0, 0, 0, 0, time.UTC),
+			domain: 500,
+			labels: []string{"Apr 2020", "Jul 2020", "Oct 2020", "Jan 2021"},
+		},
+		"weeks": {
+			start:  time.Date(2021, time.March, 3, 0, 0, 0, 0, time.UTC),
+			end:    time.Date(2021, time.March, 30, 0, 0, 0, 0, time.UTC),
+			domain: 500,
+			labels: []string{"2021-03-08", "2021-03-15", "2021-03-22", "2021-03-29"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			ticks := generateTimeTicks(&Range{
+				Min:    toFloat64(tt.start),
+				Max:    toFloat64(tt.end),
+				Domain: tt.domain,
+			})
+			var labels []string
+			for _, tick := range ticks {
+				labels = append(labels, tick.Label)
+			}
+			is.Equal(tt.labels, labels) // should snap to calendar boundaries
+		})
+	}
+}
+
+func TestGenerateElapsedTicks(t *testing.T) {
+	is := is.New(t)
+	origin := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// first starred long after the repository was created.
+	ticks := generateElapsedTicks(&Range{
+		Min:    toFloat64(origin.AddDate(0, 0, 305)),
+		Max:    toFloat64(origin.AddDate(0, 0, 1000)),
+		Domain: 500,
+	}, origin)
+	var labels []string
+	for _, tick := range ticks {
+		labels = append(labels, tick.Label)
+	}
+	is.Equal([]string{"400", "500", "600", "700", "800", "900", "1000"}, labels) // should count the days since the origin
+	is.Equal(toFloat64(origin.AddDate(0, 0, 400)), ticks[0].Value)               // should place the ticks at their day
+}
@@ -0,0 +1,163 @@
+package chart
+
+import (
+	"math"
+	"strings"
+
+	"github.com/caarlos0/starcharts/internal/chart/svg"
+)
+
+// headerHeight is the vertical space reserved above the plot for the
+// title, subtitle and legend.
+func (c *Chart) headerHeight() int {
+	height := c.titlesHeight()
+	if c.showLegend() {
+		tb := measureText(c.legendText(), LegendFontSize)
+		if c.legendInline() {
+			height = max(height, tb.Height()+TitleMargin)
+		} else {
+			height += tb.Height() + TitleMargin
+		}
+	}
+	return height
+}
+
+// titlesHeight is the vertical space taken by the title and subtitle.
+func (c *Chart) titlesHeight() int {
+	var height int
+	if c.Title != "" {
+		tb := measureText(c.Title, TitleFontSize)
+		height += tb.Height() + TitleMargin
+	}
+	if c.Subtitle != "" {
+		tb := measureText(c.Subtitle, SubtitleFontSize)
+		height += tb.Height() + TitleMargin
+	}
+	return height
+}
+
+// legendInline reports whether the legend fits on the first header line,
+// next to the title, or goes on a line of its own below the subtitle.
+func (c *Chart) legendInline() bool {
+	var tb Box
+	switch {
+	case c.Title != "":
+		tb = measureText(c.Title, TitleFontSize)
+	case c.Subtitle != "":
+		tb = measureText(c.Subtitle, SubtitleFontSize)
+	default:
+		return true
+	}
+	return BoxPadding.Left+tb.Width()+LegendMargin*2 <= c.Width-BoxPadding.Right-c.legendWidth()
+}
+
+// legendWidth is the horizontal space taken by the legend entries.
+func (c *Chart) legendWidth() int {
+	var width int
+	for i, entry := range c.legendEntries() {
+		if i > 0 {
+			width += LegendMargin * 2
+		}
+		tb := measureText(entry.series.Name, LegendFontSize)
+		width += LegendSwatchWidth + LegendMargin + tb.Width()
+	}
+	return width
+}
+
+func (c *Chart) showLegend() bool {
+	return c.Legend && c.Series.Name != ""
+}
+
+// legendEntries returns the series listed in the legend, with the class of
+// their swatch.
+func (c *Chart) legendEntries() []legendEntry {
+	entries := []legendEntry{{&c.Series, "series legend"}}
+	if c.hasSecondary() && c.SecondarySeries.Name != "" {
+		entries = append(entries, legendEntry{c.SecondarySeries, "series secondary legend"})
+	}
+	return entries
+}
+
+type legendEntry struct {
+	series *Series
+	class  string
+}
+
+// legendText returns all legend names, used to measure the legend height.
+func (c *Chart) legendText() string {
+	var names []string
+	for _, entry := range c.legendEntries() {
+		names = append(names, entry.series.Name)
+	}
+	return strings.Join(names, " ")
+}
+
+func (c *Chart) renderHeader(e *svg.Encoder) {
+	y := BoxPadding.Top
+	if c.Title != "" {
+		tb := measureText(c.Title, TitleFontSize)
+		y += tb.Height()
+		renderHeaderText(e, "title", c.Title, TitleFontSize, y)
+		y += TitleMargin
+	}
+	if c.Subtitle != "" {
+		tb := measureText(c.Subtitle, SubtitleFontSize)
+		y += tb.Height()
+		renderHeaderText(e, "subtitle", c.Subtitle, SubtitleFontSize, y)
+	}
+
+	if c.showLegend() {
+		top := BoxPadding.Top
+		if !c.legendInline() {
+			top += c.titlesHeight()
+		}
+		c.renderLegend(e, top)
+	}
+}
+
+func renderHeaderText(e *svg.Encoder, class, body string, size float64, y int) {
+	svg.Text().
+		Attr("class", class).
+		Attr("style", fontSize(size)).
+		Attr("x", svg.Point(BoxPadding.Left)).
+		Attr("y", svg.Point(y)).
+		Content(body).
+		Render(e)
+}
+
+// fontSize is the inline style setting the text size in points.
+func fontSize(size float64) string {
+	return styles("font-size", svg.Px(math.Round(pointsToPixels(DPI, size)*10)/10))
+}
+
+// renderLegend renders the series names right-aligned on the header line at
+// top, each one preceded by a swatch of its line.
+func (c *Chart) renderLegend(e *svg.Encoder, top int) {
+	entries := c.legendEntries()
+	tx := c.Width - BoxPadding.Right
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		tb := measureText(entry.series.Name, LegendFontSize)
+		ty := top + tb.Height()
+		tx -= tb.Width()
+		lx := tx - LegendMargin - LegendSwatchWidth
+		ly := ty - tb.Height()>>1
+
+		svg.Path().
+			Attr("class", entry.class).
+			Attr("stroke-width", normaliseStrokeWidth(entry.series.StrokeWidth)).
+			Attr("style", styles("stroke", entry.series.Color)).
+			MoveTo(lx, ly).
+			LineTo(lx+LegendSwatchWidth, ly).
+			Render(e)
+
+		svg.Text().
+			Attr("class", "legend").
+			Attr("x", svg.Point(tx)).
+			Attr("y", svg.Point(ty)).
+			Content(entry.series.Name).
+			Render(e)
+
+		tx = lx - LegendMargin*2
+	}
+}
@@ -0,0 +1,61 @@
+package chart
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestLegendLayout(t *testing.T) {
+	series := func(name string) Series {
+		return Series{
+			Name:    name,
+			XValues: []time.Time{time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			YValues: []float64{1, 10},
+		}
+	}
+	// y returns the baseline of the first text with the given class.
+	y := func(t *testing.T, svg, class string) int {
+		t.Helper()
+		match := regexp.MustCompile(`<text class="` + class + `"[^>]* y="(\d+)"`).FindStringSubmatch(svg)
+		is.New(t).True(match != nil) // should render the text
+		value, _ := strconv.Atoi(match[1])
+		return value
+	}
+
+	for _, tt := range []struct {
+		width  int
+		inline bool
+	}{
+		{320, false},
+		{480, false},
+		{1024, true},
+	} {
+		t.Run(strconv.Itoa(tt.width), func(t *testing.T) {
+			is := is.New(t)
+			secondary := series("golang/go")
+			c := &Chart{
+				Title:           "kubernetes/kubernetes",
+				Legend:          true,
+				Width:           tt.width,
+				Height:          400,
+				Series:          series("kubernetes/kubernetes"),
+				SecondarySeries: &secondary,
+			}
+			is.Equal(tt.inline, c.legendInline()) // should only share the title line when it fits
+
+			var sb strings.Builder
+			is.NoErr(c.Render(&sb))
+			title, legend := y(t, sb.String(), "title"), y(t, sb.String(), "legend")
+			if tt.inline {
+				is.True(legend <= title) // should render the legend next to the title
+			} else {
+				is.True(legend > title) // should render the legend below the title
+			}
+		})
+	}
+}
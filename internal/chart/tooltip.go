@@ -0,0 +1,51 @@
+package chart
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/chart/svg"
+)
+
+// renderTooltips renders an invisible hit target per data point, each one
+// with a title showing the exact date and star count on hover.
+//
+// Points sharing the same pixel column are merged, keeping the last one, so
+// huge series don't produce one element per star.
+func (c *Chart) renderTooltips(e *svg.Encoder, canvasBox *Box, xrange, yrange *Range) {
+	points := c.Series.Points(canvasBox, xrange, yrange)
+
+	var indexes []int
+	for i, p := range points {
+		if len(indexes) > 0 && points[indexes[len(indexes)-1]].X == p.X {
+			indexes[len(indexes)-1] = i
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+
+	for i, index := range indexes {
+		left, right := canvasBox.Left, canvasBox.Right
+		if i > 0 {
+			left = mean(points[indexes[i-1]].X, points[index].X)
+		}
+		if i < len(indexes)-1 {
+			right = mean(points[index].X, points[indexes[i+1]].X)
+		}
+
+		vy := c.Series.YValues[index]
+		label := fmt.Sprintf(
+			summaries[c.lang()].point,
+			c.Series.XValues[index].Format(time.DateOnly),
+			vy,
+		)
+
+		svg.Rect().
+			Attr("class", "hit").
+			Attr("x", svg.Point(left)).
+			Attr("y", svg.Point(canvasBox.Top)).
+			Attr("width", svg.Point(max(right-left, 1))).
+			Attr("height", svg.Point(canvasBox.Height())).
+			RenderChildren(e, svg.Title().Content(label).Render)
+	}
+}
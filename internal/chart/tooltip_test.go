@@ -0,0 +1,60 @@
+package chart
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestRenderTooltips(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2021, time.January, d, 0, 0, 0, 0, time.UTC)
+	}
+	render := func(t *testing.T, c *Chart) string {
+		t.Helper()
+		var sb strings.Builder
+		is.New(t).NoErr(c.Render(&sb))
+		return sb.String()
+	}
+	hit := regexp.MustCompile(`<rect class="hit" [^>]*><title>([^<]*)</title></rect>`)
+
+	t.Run("disabled", func(t *testing.T) {
+		is := is.New(t)
+		out := render(t, &Chart{Width: 1024, Height: 400, Series: Series{
+			XValues: []time.Time{day(1), day(11)},
+			YValues: []float64{1, 2},
+		}})
+		is.True(!strings.Contains(out, `class="hit"`)) // should not render hit targets
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		is := is.New(t)
+		out := render(t, &Chart{Width: 1024, Height: 400, Interactive: true, Series: Series{
+			XValues: []time.Time{day(1), day(11), day(21)},
+			YValues: []float64{1, 2, 3},
+		}})
+		var titles []string
+		for _, match := range hit.FindAllStringSubmatch(out, -1) {
+			titles = append(titles, match[1])
+		}
+		is.Equal([]string{
+			"2021-01-01: 1 stars",
+			"2021-01-11: 2 stars",
+			"2021-01-21: 3 stars",
+		}, titles) // should show the date and stars of every point
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		is := is.New(t)
+		series := Series{}
+		for i := range 1000 {
+			series.XValues = append(series.XValues, day(1).Add(time.Duration(i)*time.Minute))
+			series.YValues = append(series.YValues, float64(i+1))
+		}
+		out := render(t, &Chart{Width: 320, Height: 200, Interactive: true, Series: series})
+		is.True(len(hit.FindAllString(out, -1)) < 320) // should merge the points sharing a column
+	})
+}
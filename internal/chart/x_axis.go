@@ -1,8 +1,8 @@
 package chart
 
 import (
-	"io"
 	"math"
+	"time"
 
 	"github.com/caarlos0/starcharts/internal/chart/svg"
 )
@@ -11,6 +11,27 @@ type XAxis struct {
 	Name        string
 	StrokeWidth float64
 	Color       string
+
+	// ValueFormatter formats the tick labels. When unset, ticks are snapped
+	// to calendar boundaries with a matching date format.
+	ValueFormatter ValueFormatter
+
+	// Origin, when set, labels the ticks with the days elapsed since it
+	// instead of dates.
+	Origin time.Time
+
+	// Grid configures the vertical grid lines.
+	Grid Grid
+}
+
+func (xa *XAxis) ticks(ra *Range) []Tick {
+	if xa.ValueFormatter != nil {
+		return generateTicks(ra, false, xa.ValueFormatter)
+	}
+	if !xa.Origin.IsZero() {
+		return generateElapsedTicks(ra, xa.Origin)
+	}
+	return generateTimeTicks(ra)
 }
 
 func (xa *XAxis) Measure(canvas *Box, ra *Range, ticks []Tick) *Box {
@@ -42,7 +63,13 @@ func (xa *XAxis) Measure(canvas *Box, ra *Range, ticks []Tick) *Box {
 	}
 }
 
-func (xa *XAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
+func (xa *XAxis) Render(e *svg.Encoder, canvasBox *Box, ra *Range, ticks []Tick) {
+	svg.G().Attr("class", "x-axis").RenderChildren(e, func(e *svg.Encoder) {
+		xa.render(e, canvasBox, ra, ticks)
+	})
+}
+
+func (xa *XAxis) render(e *svg.Encoder, canvasBox *Box, ra *Range, ticks []Tick) {
 	strokeWidth := normaliseStrokeWidth(xa.StrokeWidth)
 	strokeStyle := styles("stroke", xa.Color)
 	fillStyle := styles("fill", xa.Color)
@@ -52,7 +79,7 @@ func (xa *XAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
 		Attr("style", strokeStyle).
 		MoveToF(float64(canvasBox.Left)-xa.StrokeWidth/2, float64(canvasBox.Bottom)).
 		LineTo(canvasBox.Right, canvasBox.Bottom).
-		Render(w)
+		Render(e)
 
 	var tx, ty int
 	var maxTextHeight int
@@ -67,7 +94,7 @@ func (xa *XAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
 			Attr("style", strokeStyle).
 			MoveTo(tx, canvasBox.Bottom).
 			LineTo(tx, canvasBox.Bottom+VerticalTickHeight).
-			Render(w)
+			Render(e)
 
 		tb := measureText(t.Label, AxisFontSize)
 
@@ -79,7 +106,7 @@ func (xa *XAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
 			Attr("style", fillStyle).
 			Attr("x", svg.Point(tx)).
 			Attr("y", svg.Point(ty)).
-			Render(w)
+			Render(e)
 
 		maxTextHeight = max(maxTextHeight, tb.Height())
 	}
@@ -93,5 +120,5 @@ func (xa *XAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
 		Attr("style", fillStyle).
 		Attr("x", svg.Point(tx)).
 		Attr("y", svg.Point(ty)).
-		Render(w)
+		Render(e)
 }
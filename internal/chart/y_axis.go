@@ -1,50 +1,86 @@
 package chart
 
 import (
-	"io"
 	"math"
 
 	"github.com/caarlos0/starcharts/internal/chart/svg"
 )
 
+// AxisPosition is the side of the plot a Y axis is drawn on.
+type AxisPosition int
+
+const (
+	// AxisRight draws the axis on the right of the plot, the default.
+	AxisRight AxisPosition = iota
+	// AxisLeft draws the axis on the left of the plot.
+	AxisLeft
+)
+
 type YAxis struct {
 	Name        string
 	StrokeWidth float64
 	Color       string
+
+	// Position is the side of the plot the axis is drawn on.
+	Position AxisPosition
+
+	// ValueFormatter formats the tick labels, defaults to CompactValueFormatter.
+	ValueFormatter ValueFormatter
+
+	// Grid configures the horizontal grid lines.
+	Grid Grid
 }
 
-func (ya *YAxis) Measure(canvas *Box, ra *Range, ticks []Tick) *Box {
-	tx := canvas.Right + YAxisMargin
+// formatter returns the tick labels formatter for ticks step apart.
+func (ya *YAxis) formatter(step float64) ValueFormatter {
+	if ya.ValueFormatter != nil {
+		return ya.ValueFormatter
+	}
+	return CompactStepValueFormatter(step)
+}
 
-	minX, maxX, minY, maxY := math.MaxInt32, 0, math.MaxInt32, 0
-	maxTextHeight := 0
+func (ya *YAxis) Measure(canvas *Box, ra *Range, ticks []Tick) *Box {
+	minY, maxY := math.MaxInt32, 0
+	maxTextWidth, maxTextHeight := 0, 0
 	for _, t := range ticks {
 		ly := canvas.Bottom - ra.Translate(t.Value)
 
 		tb := measureText(t.Label, AxisFontSize)
-		maxTextHeight = max(tb.Height(), maxTextHeight)
-
-		minX = canvas.Right
-		maxX = max(maxX, tx+tb.Width())
+		maxTextWidth = max(maxTextWidth, tb.Width())
+		maxTextHeight = max(maxTextHeight, tb.Height())
 
 		tbh2 := tb.Height() >> 1
 		minY = min(minY, ly-tbh2)
 		maxY = max(maxY, ly+tbh2)
 	}
 
-	maxX += YAxisMargin + maxTextHeight
+	width := YAxisMargin + maxTextWidth + YAxisMargin + maxTextHeight
 
-	return &Box{
+	box := &Box{
 		Top:    minY,
-		Left:   minX,
-		Right:  maxX,
+		Left:   canvas.Right,
+		Right:  canvas.Right + width,
 		Bottom: maxY,
 	}
+	if ya.Position == AxisLeft {
+		box.Left, box.Right = canvas.Left-width, canvas.Left
+	}
+	return box
+}
+
+func (ya *YAxis) Render(e *svg.Encoder, canvasBox *Box, ra *Range, ticks []Tick) {
+	svg.G().Attr("class", "y-axis").RenderChildren(e, func(e *svg.Encoder) {
+		ya.render(e, canvasBox, ra, ticks)
+	})
 }
 
-func (ya *YAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
-	lx := canvasBox.Right
-	tx := lx + YAxisMargin
+func (ya *YAxis) render(e *svg.Encoder, canvasBox *Box, ra *Range, ticks []Tick) {
+	// dir is the direction ticks and labels grow away from the plot.
+	lx, dir, anchor := canvasBox.Right, 1, "start"
+	if ya.Position == AxisLeft {
+		lx, dir, anchor = canvasBox.Left, -1, "end"
+	}
+	tx := lx + dir*YAxisMargin
 	strokeStyle := styles("stroke", ya.Color)
 	fillStyle := styles("fill", ya.Color)
 
@@ -55,7 +91,7 @@ func (ya *YAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
 		Attr("style", strokeStyle).
 		MoveTo(lx, canvasBox.Bottom).
 		LineToF(float64(lx), float64(canvasBox.Top)-ya.StrokeWidth/2).
-		Render(w)
+		Render(e)
 
 	var maxTextWidth int
 	var finalTextY int
@@ -73,26 +109,33 @@ func (ya *YAxis) Render(w io.Writer, canvasBox *Box, ra *Range, ticks []Tick) {
 			Attr("stroke-width", strokeWidth).
 			Attr("style", strokeStyle).
 			MoveTo(lx, ly).
-			LineTo(lx+HorizontalTickWidth, ly).
-			Render(w)
+			LineTo(lx+dir*HorizontalTickWidth, ly).
+			Render(e)
 
-		svg.Text().
+		text := svg.Text().
 			Content(t.Label).
 			Attr("style", fillStyle).
 			Attr("x", svg.Point(tx)).
-			Attr("y", svg.Point(finalTextY)).
-			Render(w)
+			Attr("y", svg.Point(finalTextY))
+		if ya.Position == AxisLeft {
+			text.Attr("text-anchor", anchor)
+		}
+		text.Render(e)
 	}
 
 	tb := measureText(ya.Name, AxisFontSize)
-	tx = canvasBox.Right + YAxisMargin + maxTextWidth + YAxisMargin
+	tx = lx + dir*(YAxisMargin+maxTextWidth+YAxisMargin)
 	ty := canvasBox.Top + (canvasBox.Height()>>1 - tb.Height()>>1)
+	if ya.Position == AxisLeft {
+		// rotated counter-clockwise, the name reads upwards from ty.
+		ty = canvasBox.Top + (canvasBox.Height()>>1 + tb.Width()>>1)
+	}
 
 	svg.Text().
 		Content(ya.Name).
 		Attr("x", svg.Point(tx)).
 		Attr("y", svg.Point(ty)).
 		Attr("style", fillStyle).
-		Attr("transform", rotate(90, tx, ty)).
-		Render(w)
+		Attr("transform", svg.Rotate(float64(dir*90), tx, ty)).
+		Render(e)
 }
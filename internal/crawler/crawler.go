@@ -0,0 +1,298 @@
+// Package crawler crawls repositories stargazers in the background, with a
+// pool of workers taking jobs from a queue.
+package crawler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/caarlos0/starcharts/internal/github"
+	"github.com/caarlos0/starcharts/internal/queue"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Timeout bounds a crawl.
+	Timeout = 5 * time.Minute
+	// RetryAfter is how long a failed job is kept before it may be enqueued
+	// again, unless github told when to retry.
+	RetryAfter = time.Minute
+
+	// pollInterval is how often Wait checks a job status.
+	pollInterval = 250 * time.Millisecond
+	// popBackoff is how long a worker waits after failing to pop a job.
+	popBackoff = time.Second
+)
+
+var jobs = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "starcharts",
+	Subsystem: "crawler",
+	Name:      "jobs_total",
+	Help:      "Total number of crawl jobs, by final state",
+}, []string{"state"})
+
+func init() {
+	prometheus.MustRegister(jobs)
+}
+
+// Result of a crawl, what a chart is drawn from.
+type Result struct {
+	Repo       github.Repository
+	Stargazers []github.Stargazer
+}
+
+// CrawlFunc crawls the given repository.
+type CrawlFunc func(ctx context.Context, name string) (Result, error)
+
+// GitHub crawls the repository details and stargazers from github.
+func GitHub(gh *github.GitHub) CrawlFunc {
+	return func(ctx context.Context, name string) (Result, error) {
+		repo, err := gh.RepoDetails(ctx, name)
+		if err != nil {
+			return Result{}, err
+		}
+		stargazers, err := gh.Stargazers(ctx, repo)
+		if err != nil {
+			return Result{}, err
+		}
+		return Result{Repo: repo, Stargazers: stargazers}, nil
+	}
+}
+
+// Pool of workers crawling the repositories pushed to a queue. Results and
+// job statuses are cached, so every instance sharing the cache can see them.
+type Pool struct {
+	cache   *cache.Redis
+	queue   queue.Queue
+	workers int
+	crawl   CrawlFunc
+
+	// statuses are the statuses of this instance jobs, used when the cache
+	// is unavailable and to keep workers from running the same job.
+	lock     sync.Mutex
+	statuses map[string]Status
+}
+
+// NewPool returns a pool of the given number of workers, crawling with the
+// given function.
+func NewPool(crawl CrawlFunc, cache *cache.Redis, queue queue.Queue, workers int) *Pool {
+	return &Pool{
+		cache:    cache,
+		queue:    queue,
+		workers:  max(workers, 1),
+		crawl:    crawl,
+		statuses: map[string]Status{},
+	}
+}
+
+// Start starts the workers, which stop once ctx is done.
+func (p *Pool) Start(ctx context.Context) {
+	for range p.workers {
+		go p.work(ctx)
+	}
+}
+
+func (p *Pool) work(ctx context.Context) {
+	for {
+		name, err := p.queue.Pop(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("failed to pop crawl job", "error", err)
+			select {
+			case <-time.After(popBackoff):
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		p.run(ctx, name)
+	}
+}
+
+// run crawls the given repository, unless another worker already did or is
+// doing it, as a job may be queued more than once.
+func (p *Pool) run(ctx context.Context, name string) {
+	log := slog.With("repo", name)
+	now := time.Now()
+	if status, ok := p.Status(name); ok {
+		switch {
+		case status.State == StateDone && p.hasResult(name):
+			log.Debug("already crawled")
+			return
+		case status.State == StateRunning && !status.stale(now):
+			log.Debug("already running")
+			return
+		}
+	}
+	if !p.claim(name, now) {
+		log.Debug("already running")
+		return
+	}
+
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	result, err := p.crawl(ctx, name)
+	log.Debug("crawl", "duration", time.Since(start))
+	if err != nil {
+		log.Warn("crawl failed", "error", err)
+		jobs.WithLabelValues(string(StateFailed)).Inc()
+		p.setStatus(name, newFailedStatus(err, time.Now()))
+		return
+	}
+
+	if err := p.cache.Put(resultKey(name), result); err != nil {
+		log.Error("failed to cache crawl", "error", err)
+	}
+	jobs.WithLabelValues(string(StateDone)).Inc()
+	p.setStatus(name, Status{State: StateDone, UpdatedAt: time.Now()})
+}
+
+// claim marks the job as running, unless a worker of this instance is
+// already running it.
+func (p *Pool) claim(name string, now time.Time) bool {
+	status := Status{State: StateRunning, UpdatedAt: now}
+
+	p.lock.Lock()
+	if local, ok := p.statuses[name]; ok && local.State == StateRunning && !local.stale(now) {
+		p.lock.Unlock()
+		return false
+	}
+	p.statuses[name] = status
+	p.lock.Unlock()
+
+	p.cacheStatus(name, status)
+	return true
+}
+
+// Enqueue queues a crawl of the given repository, unless it is already
+// queued, running, recently failed or crawled, and returns its status.
+func (p *Pool) Enqueue(ctx context.Context, name string) (Status, error) {
+	return p.enqueue(ctx, name, false)
+}
+
+// Refresh queues a crawl of the given repository even if it was crawled,
+// unless it is already queued, running or recently failed, and returns its
+// status. The previous result is kept until the new crawl is done.
+func (p *Pool) Refresh(ctx context.Context, name string) (Status, error) {
+	return p.enqueue(ctx, name, true)
+}
+
+func (p *Pool) enqueue(ctx context.Context, name string, refresh bool) (Status, error) {
+	now := time.Now()
+	if status, ok := p.Status(name); ok {
+		switch {
+		case status.State == StateDone && !refresh && p.hasResult(name):
+			return status, nil
+		case status.State != StateDone && !status.stale(now):
+			return status, nil
+		}
+	}
+
+	status := Status{State: StateQueued, UpdatedAt: now}
+	p.setStatus(name, status)
+	if err := p.queue.Push(ctx, name); err != nil {
+		p.deleteStatus(name)
+		return Status{}, err
+	}
+	return status, nil
+}
+
+// Status returns the status of the given repository crawl, and whether there
+// is one. The cached status is preferred, falling back to the statuses of
+// this instance jobs when the cache is unavailable.
+func (p *Pool) Status(name string) (Status, bool) {
+	var status Status
+	if err := p.cache.Get(statusKey(name), &status); err == nil {
+		return status, true
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	status, ok := p.statuses[name]
+	return status, ok
+}
+
+// Result returns the cached crawl of the given repository.
+func (p *Pool) Result(name string) (Result, error) {
+	var result Result
+	err := p.cache.Get(resultKey(name), &result)
+	return result, err
+}
+
+// Wait waits at most timeout for the given repository crawl to be done or
+// to fail, and returns its last status.
+func (p *Pool) Wait(ctx context.Context, name string, timeout time.Duration) (Status, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, ok := p.Status(name)
+		if ok && !status.pending() {
+			return status, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-timer.C:
+			return status, nil
+		case <-ctx.Done():
+			return status, ctx.Err()
+		}
+	}
+}
+
+// setStatus records the status of a job, both in memory and in the cache.
+// Done jobs are only kept in the cache, next to their result, and expired
+// statuses are pruned, so the statuses kept in memory don't grow unbounded.
+func (p *Pool) setStatus(name string, status Status) {
+	p.lock.Lock()
+	if status.State == StateDone {
+		delete(p.statuses, name)
+	} else {
+		p.statuses[name] = status
+	}
+	for other, s := range p.statuses {
+		if s.expired(status.UpdatedAt) {
+			delete(p.statuses, other)
+		}
+	}
+	p.lock.Unlock()
+
+	p.cacheStatus(name, status)
+}
+
+func (p *Pool) cacheStatus(name string, status Status) {
+	if err := p.cache.Put(statusKey(name), status); err != nil {
+		slog.Warn("failed to cache crawl status", "repo", name, "error", err)
+	}
+}
+
+func (p *Pool) deleteStatus(name string) {
+	p.lock.Lock()
+	delete(p.statuses, name)
+	p.lock.Unlock()
+
+	if err := p.cache.Delete(statusKey(name)); err != nil {
+		slog.Warn("failed to delete from cache", "repo", name, "error", err)
+	}
+}
+
+func (p *Pool) hasResult(name string) bool {
+	return p.cache.Exists(resultKey(name))
+}
+
+func resultKey(name string) string {
+	return name + "_crawl"
+}
+
+func statusKey(name string) string {
+	return name + "_crawl_status"
+}
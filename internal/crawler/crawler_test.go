@@ -0,0 +1,176 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/caarlos0/starcharts/internal/github"
+	"github.com/caarlos0/starcharts/internal/queue"
+	"github.com/go-redis/redis"
+	"github.com/matryer/is"
+)
+
+func TestPool(t *testing.T) {
+	mr, _ := miniredis.Run()
+	t.Cleanup(mr.Close)
+	rc := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	cache := cache.New(rc)
+	t.Cleanup(func() { _ = cache.Close() })
+
+	var crawls atomic.Int32
+	retryAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	slow, duplicated := make(chan struct{}), make(chan struct{})
+	pool := NewPool(func(_ context.Context, name string) (Result, error) {
+		switch name {
+		case "rate/limited":
+			return Result{}, &github.RateLimitError{RetryAt: retryAt}
+		case "not/found":
+			return Result{}, github.ErrorNotFound
+		case "some/error":
+			return Result{}, errors.New("boom")
+		case "slow/test":
+			<-slow
+		case "duplicated/test":
+			crawls.Add(1)
+			<-duplicated
+		default:
+			crawls.Add(1)
+		}
+		return Result{Repo: github.Repository{FullName: name}}, nil
+	}, cache, queue.NewMemory(10), 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	pool.Start(ctx)
+
+	t.Run("crawl", func(t *testing.T) {
+		is := is.New(t)
+		crawls.Store(0)
+
+		_, err := pool.Enqueue(ctx, "test/test")
+		is.NoErr(err)
+
+		status, err := pool.Wait(ctx, "test/test", 5*time.Second)
+		is.NoErr(err)
+		is.Equal(StateDone, status.State) // should be crawled
+
+		result, err := pool.Result("test/test")
+		is.NoErr(err)
+		is.Equal("test/test", result.Repo.FullName) // should cache the result
+
+		status, err = pool.Enqueue(ctx, "test/test")
+		is.NoErr(err)
+		is.Equal(StateDone, status.State) // should not enqueue a crawled repository again
+		is.Equal(int32(1), crawls.Load()) // should crawl only once
+
+		status, err = pool.Refresh(ctx, "test/test")
+		is.NoErr(err)
+		is.Equal(StateQueued, status.State) // should enqueue a crawled repository again
+
+		status, err = pool.Wait(ctx, "test/test", 5*time.Second)
+		is.NoErr(err)
+		is.Equal(StateDone, status.State) // should be crawled again
+		is.Equal(int32(2), crawls.Load()) // should crawl twice
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		is := is.New(t)
+		for name, check := range map[string]func(err error){
+			"rate/limited": func(err error) {
+				var rateLimit *github.RateLimitError
+				is.True(errors.As(err, &rateLimit))       // should be a rate limit
+				is.True(rateLimit.RetryAt.Equal(retryAt)) // should keep when to retry
+			},
+			"not/found": func(err error) {
+				is.True(errors.Is(err, github.ErrorNotFound)) // should be not found
+			},
+			"some/error": func(err error) {
+				is.Equal("boom", err.Error()) // should keep the error message
+			},
+		} {
+			_, err := pool.Enqueue(ctx, name)
+			is.NoErr(err)
+
+			status, err := pool.Wait(ctx, name, 5*time.Second)
+			is.NoErr(err)
+			is.Equal(StateFailed, status.State) // should fail
+			check(status.Err())
+
+			status, err = pool.Enqueue(ctx, name)
+			is.NoErr(err)
+			is.Equal(StateFailed, status.State) // should not retry right away
+		}
+	})
+
+	t.Run("wait", func(t *testing.T) {
+		is := is.New(t)
+		t.Cleanup(func() { close(slow) })
+
+		_, err := pool.Enqueue(ctx, "slow/test")
+		is.NoErr(err)
+
+		status, err := pool.Wait(ctx, "slow/test", 600*time.Millisecond)
+		is.NoErr(err)
+		is.True(status.pending()) // should give up waiting while the crawl runs
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		is := is.New(t)
+		crawls.Store(0)
+
+		// the same job queued twice, e.g. by two instances.
+		is.NoErr(pool.queue.Push(ctx, "duplicated/test"))
+		is.NoErr(pool.queue.Push(ctx, "duplicated/test"))
+		time.Sleep(100 * time.Millisecond)
+		close(duplicated)
+
+		status, err := pool.Wait(ctx, "duplicated/test", 5*time.Second)
+		is.NoErr(err)
+		is.Equal(StateDone, status.State) // should be crawled
+		is.Equal(int32(1), crawls.Load()) // should crawl only once
+	})
+
+	t.Run("lost queue", func(t *testing.T) {
+		is := is.New(t)
+
+		// queued before a restart lost the memory queue.
+		pool.setStatus("lost/test", Status{State: StateQueued, UpdatedAt: time.Now().Add(-2 * Timeout)})
+
+		status, err := pool.Enqueue(ctx, "lost/test")
+		is.NoErr(err)
+		is.Equal(StateQueued, status.State) // should enqueue a stale queued job again
+
+		status, err = pool.Wait(ctx, "lost/test", 5*time.Second)
+		is.NoErr(err)
+		is.Equal(StateDone, status.State) // should be crawled
+	})
+}
+
+func TestPool_Prune(t *testing.T) {
+	is := is.New(t)
+	mr, _ := miniredis.Run()
+	t.Cleanup(mr.Close)
+	rc := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	cache := cache.New(rc)
+	t.Cleanup(func() { _ = cache.Close() })
+
+	pool := NewPool(nil, cache, queue.NewMemory(10), 2)
+	now := time.Now()
+
+	pool.setStatus("old/queued", Status{State: StateQueued, UpdatedAt: now.Add(-2 * Timeout)})
+	pool.setStatus("old/failed", Status{State: StateFailed, UpdatedAt: now.Add(-2 * RetryAfter)})
+	pool.setStatus("new/failed", Status{State: StateFailed, UpdatedAt: now})
+
+	is.Equal(1, len(pool.statuses)) // should prune the expired statuses
+	_, ok := pool.statuses["new/failed"]
+	is.True(ok) // should keep the recent statuses
+}
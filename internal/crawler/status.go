@@ -0,0 +1,116 @@
+package crawler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/github"
+)
+
+// State of a crawl job.
+type State string
+
+const (
+	// StateQueued is a job waiting for a worker.
+	StateQueued State = "queued"
+	// StateRunning is a job being crawled by a worker.
+	StateRunning State = "running"
+	// StateDone is a job whose result is cached.
+	StateDone State = "done"
+	// StateFailed is a job that couldn't be crawled.
+	StateFailed State = "failed"
+)
+
+// error kinds, so a failed job error can be told apart once it's been
+// through the cache.
+const (
+	kindNotFound  = "not_found"
+	kindPrivate   = "private"
+	kindRateLimit = "rate_limit"
+)
+
+// Status of a crawl job.
+type Status struct {
+	State State `json:"state"`
+	// Error is why a failed job failed.
+	Error string `json:"error,omitempty"`
+	// Kind is the kind of Error, if known.
+	Kind string `json:"-"`
+	// RetryAt is when a rate limited job may be retried, if known.
+	RetryAt time.Time `json:"retry_at,omitzero"`
+	// UpdatedAt is when the job last changed state.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newFailedStatus records err in a failed status.
+func newFailedStatus(err error, now time.Time) Status {
+	status := Status{
+		State:     StateFailed,
+		Error:     err.Error(),
+		UpdatedAt: now,
+	}
+	var rateLimit *github.RateLimitError
+	switch {
+	case errors.As(err, &rateLimit):
+		status.Kind = kindRateLimit
+		status.RetryAt = rateLimit.RetryAt
+	case errors.Is(err, github.ErrRateLimit):
+		status.Kind = kindRateLimit
+	case errors.Is(err, github.ErrorNotFound):
+		status.Kind = kindNotFound
+	case errors.Is(err, github.ErrPrivateRepository):
+		status.Kind = kindPrivate
+	}
+	return status
+}
+
+// Err returns why the job failed, matching the github errors it failed with.
+func (s Status) Err() error {
+	if s.State != StateFailed {
+		return nil
+	}
+	switch s.Kind {
+	case kindRateLimit:
+		return &github.RateLimitError{RetryAt: s.RetryAt}
+	case kindNotFound:
+		return github.ErrorNotFound
+	case kindPrivate:
+		return github.ErrPrivateRepository
+	default:
+		return errors.New(s.Error)
+	}
+}
+
+// pending reports whether the job is still waiting for, or being crawled by,
+// a worker.
+func (s Status) pending() bool {
+	return s.State == StateQueued || s.State == StateRunning
+}
+
+// stale reports whether a job should be enqueued again: it has been queued
+// or running for longer than a crawl may take, e.g. its queue was lost on a
+// restart, or it failed and may be retried. A job queued twice is crawled
+// once, as its second run finds it done.
+func (s Status) stale(now time.Time) bool {
+	switch s.State {
+	case StateQueued, StateRunning:
+		return now.Sub(s.UpdatedAt) > Timeout
+	case StateFailed:
+		if s.Kind == kindRateLimit && !s.RetryAt.IsZero() {
+			return now.After(s.RetryAt)
+		}
+		return now.Sub(s.UpdatedAt) > RetryAfter
+	default:
+		return false
+	}
+}
+
+// expired reports whether the status is no longer worth keeping in memory:
+// the job failed and may be retried, or it hasn't changed for longer than a
+// crawl may take.
+func (s Status) expired(now time.Time) bool {
+	if s.State == StateFailed {
+		return s.stale(now)
+	}
+	return now.Sub(s.UpdatedAt) > Timeout
+}
@@ -1,12 +1,15 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/caarlos0/starcharts/config"
 	"github.com/caarlos0/starcharts/internal/cache"
@@ -20,6 +23,40 @@ var ErrRateLimit = errors.New("rate limited, please try again later")
 // ErrGitHubAPI happens when github responds with something other than a 2xx.
 var ErrGitHubAPI = errors.New("failed to talk with github api")
 
+// RateLimitError is a rate limit response, with when to retry if github told
+// us. It matches ErrRateLimit.
+type RateLimitError struct {
+	RetryAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return ErrRateLimit.Error()
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimit
+}
+
+// newRateLimitError reads when to retry from the Retry-After or
+// X-RateLimit-Reset headers of a rate limited response.
+func newRateLimitError(resp *http.Response) error {
+	rateLimits.Inc()
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return &RateLimitError{RetryAt: time.Now().Add(time.Duration(seconds) * time.Second)}
+	}
+	if reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return &RateLimitError{RetryAt: time.Unix(reset, 0)}
+	}
+	return &RateLimitError{}
+}
+
+// isRateLimited tells whether github rate limited the response, which it
+// does with either a 403 or a 429.
+func isRateLimited(resp *http.Response) bool {
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusForbidden
+}
+
 // GitHub client struct.
 type GitHub struct {
 	tokens          roundrobin.RoundRobiner
@@ -101,43 +138,89 @@ func (gh *GitHub) authorizedDo(req *http.Request, try int) (*http.Response, erro
 }
 
 func (gh *GitHub) checkToken(token *roundrobin.Token) error {
-	req, err := http.NewRequest(http.MethodGet, "https://api.github.com/rate_limit", nil)
+	rate, err := gh.rate(context.Background(), token)
 	if err != nil {
 		return err
 	}
-	req.Header.Add("Authorization", fmt.Sprintf("token %s", token.Key()))
+	if isAboveTargetUsage(rate, gh.maxRateUsagePct) {
+		return fmt.Errorf("token usage is too high: %d/%d", rate.Remaining, rate.Limit)
+	}
+	return nil // allow at most x% rate limit usage
+}
+
+// rateUsageTimeout bounds the rate limit requests of RateUsage.
+const rateUsageTimeout = 10 * time.Second
+
+// RateUsage returns the percentage of the overall rate limit used by all the
+// valid tokens, or by unauthenticated requests when there are none.
+func (gh *GitHub) RateUsage(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, rateUsageTimeout)
+	defer cancel()
+
+	tokens := gh.tokens.Valid()
+	if len(tokens) == 0 {
+		tokens = []*roundrobin.Token{nil}
+	}
+
+	var total rate
+	var errs []error
+	for _, token := range tokens {
+		rate, err := gh.rate(ctx, token)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total.Remaining += rate.Remaining
+		total.Limit += rate.Limit
+	}
+	if len(errs) == len(tokens) {
+		return 0, errors.Join(errs...)
+	}
+	if total.Limit == 0 {
+		return 0, nil
+	}
+	return 100 - total.Remaining*100/total.Limit, nil
+}
+
+// rate gets the rate limit of the given token, which may be nil.
+func (gh *GitHub) rate(ctx context.Context, token *roundrobin.Token) (rate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/rate_limit", nil)
+	if err != nil {
+		return rate{}, err
+	}
+	if token != nil {
+		req.Header.Add("Authorization", fmt.Sprintf("token %s", token.Key()))
+	}
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return err
+		return rate{}, err
 	}
 	defer resp.Body.Close() //nolint:errcheck
 
-	if resp.StatusCode == http.StatusUnauthorized {
+	if resp.StatusCode == http.StatusUnauthorized && token != nil {
 		token.Invalidate()
 		invalidatedTokens.Inc()
-		return fmt.Errorf("token is invalid")
+		return rate{}, fmt.Errorf("token is invalid")
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+		return rate{}, fmt.Errorf("request failed with status %d", resp.StatusCode)
 	}
 
 	bts, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return rate{}, err
 	}
 
 	var limit rateLimit
 	if err := json.Unmarshal(bts, &limit); err != nil {
-		return err
+		return rate{}, err
 	}
-	rate := limit.Rate
-	slog.Debug(fmt.Sprintf("%s rate %d/%d", token, rate.Remaining, rate.Limit))
-	rateLimiters.WithLabelValues(token.String()).Set(float64(rate.Remaining))
-	if isAboveTargetUsage(rate, gh.maxRateUsagePct) {
-		return fmt.Errorf("token usage is too high: %d/%d", rate.Remaining, rate.Limit)
+	if token != nil {
+		slog.Debug(fmt.Sprintf("%s rate %d/%d", token, limit.Rate.Remaining, limit.Rate.Limit))
+		rateLimiters.WithLabelValues(token.String()).Set(float64(limit.Rate.Remaining))
 	}
-	return nil // allow at most x% rate limit usage
+	return limit.Rate, nil
 }
 
 func isAboveTargetUsage(rate rate, target int) bool {
@@ -1,9 +1,13 @@
 package github
 
 import (
+	"context"
 	"testing"
 
+	"github.com/caarlos0/starcharts/config"
+	"github.com/caarlos0/starcharts/internal/roundrobin"
 	"github.com/matryer/is"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestIsRateAboveLimit(t *testing.T) {
@@ -29,3 +33,43 @@ func TestIsRateAboveLimit(t *testing.T) {
 		Limit:     5000,
 	}, 80))
 }
+
+func TestRateUsage(t *testing.T) {
+	t.Run("unauthenticated", func(t *testing.T) {
+		defer gock.Off()
+		is := is.New(t)
+
+		gock.New("https://api.github.com").
+			Get("/rate_limit").
+			Reply(200).
+			JSON(rateLimit{rate{Limit: 60, Remaining: 48}})
+
+		gt := New(config.Get(), nil)
+		gt.tokens = roundrobin.New(nil)
+		usage, err := gt.RateUsage(context.TODO())
+		is.NoErr(err)
+		is.Equal(20, usage) // should use 20% of the rate limit
+	})
+
+	t.Run("all tokens", func(t *testing.T) {
+		defer gock.Off()
+		is := is.New(t)
+
+		gock.New("https://api.github.com").
+			Get("/rate_limit").
+			MatchHeader("Authorization", "token 12345").
+			Reply(200).
+			JSON(rateLimit{rate{Limit: 5000, Remaining: 5000}})
+		gock.New("https://api.github.com").
+			Get("/rate_limit").
+			MatchHeader("Authorization", "token 67890").
+			Reply(200).
+			JSON(rateLimit{rate{Limit: 5000, Remaining: 1000}})
+
+		gt := New(config.Get(), nil)
+		gt.tokens = roundrobin.New([]string{"12345", "67890"})
+		usage, err := gt.RateUsage(context.TODO())
+		is.NoErr(err)
+		is.Equal(40, usage) // should sum the usage of every token
+	})
+}
@@ -15,10 +15,15 @@ type Repository struct {
 	FullName        string `json:"full_name"`
 	StargazersCount int    `json:"stargazers_count"`
 	CreatedAt       string `json:"created_at"`
+	Private         bool   `json:"private"`
 }
 
 var ErrorNotFound = errors.New("Repository not found")
 
+// ErrPrivateRepository happens when the repository is visible to our tokens,
+// but isn't public.
+var ErrPrivateRepository = errors.New("Repository is private")
+
 // RepoDetails gets the given repository details.
 func (gh *GitHub) RepoDetails(ctx context.Context, name string) (Repository, error) {
 	var repo Repository
@@ -54,11 +59,10 @@ func (gh *GitHub) RepoDetails(ctx context.Context, name string) (Repository, err
 			}
 			return gh.RepoDetails(ctx, name)
 		}
-		return repo, err
-	case http.StatusForbidden:
-		rateLimits.Inc()
+		return repo, checkPublic(repo)
+	case http.StatusForbidden, http.StatusTooManyRequests:
 		log.Warn("rate limit hit")
-		return repo, ErrRateLimit
+		return repo, newRateLimitError(resp)
 	case http.StatusOK:
 		if err := json.Unmarshal(bts, &repo); err != nil {
 			return repo, err
@@ -74,7 +78,7 @@ func (gh *GitHub) RepoDetails(ctx context.Context, name string) (Repository, err
 			}
 		}
 
-		return repo, nil
+		return repo, checkPublic(repo)
 	case http.StatusNotFound:
 		return repo, ErrorNotFound
 	default:
@@ -82,6 +86,13 @@ func (gh *GitHub) RepoDetails(ctx context.Context, name string) (Repository, err
 	}
 }
 
+func checkPublic(repo Repository) error {
+	if repo.Private {
+		return ErrPrivateRepository
+	}
+	return nil
+}
+
 func (gh *GitHub) makeRepoRequest(ctx context.Context, name, etag string) (*http.Response, error) {
 	url := fmt.Sprintf("https://api.github.com/repos/%s", name)
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
@@ -2,7 +2,9 @@ package github
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis"
 	"github.com/caarlos0/starcharts/config"
@@ -76,6 +78,16 @@ func TestRepoDetails_APIfailure(t *testing.T) {
 		Get("/repos/private/private").
 		Reply(403)
 
+	gock.New("https://api.github.com").
+		Get("/repos/limited/limited").
+		Reply(429).
+		SetHeader("Retry-After", "60")
+
+	gock.New("https://api.github.com").
+		Get("/repos/hidden/hidden").
+		Reply(200).
+		JSON(Repository{FullName: "hidden/hidden", Private: true})
+
 	mr, _ := miniredis.Run()
 	rc := redis.NewClient(&redis.Options{
 		Addr: mr.Addr(),
@@ -96,6 +108,20 @@ func TestRepoDetails_APIfailure(t *testing.T) {
 		_, err := gt.RepoDetails(context.TODO(), "private/private")
 		is.True(err != nil) // Expected error
 	})
+	t.Run("set retry time if api return 429", func(t *testing.T) {
+		is := is.New(t)
+		_, err := gt.RepoDetails(context.TODO(), "limited/limited")
+		is.True(errors.Is(err, ErrRateLimit)) // should be a rate limit
+		var rateLimit *RateLimitError
+		is.True(errors.As(err, &rateLimit))                      // should tell when to retry
+		is.True(time.Until(rateLimit.RetryAt) > 50*time.Second)  // should retry after the header
+		is.True(time.Until(rateLimit.RetryAt) <= 60*time.Second) // should retry after the header
+	})
+	t.Run("set error if repo is private", func(t *testing.T) {
+		is := is.New(t)
+		_, err := gt.RepoDetails(context.TODO(), "hidden/hidden")
+		is.True(errors.Is(err, ErrPrivateRepository)) // Expected error
+	})
 }
 
 func TestRepoDetails_WithAuthToken(t *testing.T) {
@@ -70,10 +70,9 @@ func (gh *GitHub) getFirstPageAndLastPage(ctx context.Context, repo Repository)
 	}
 	defer resp.Body.Close() //nolint:errcheck
 
-	if resp.StatusCode == http.StatusForbidden {
-		rateLimits.Inc()
+	if isRateLimited(resp) {
 		log.Warn("rate limit hit")
-		return nil, 0, ErrRateLimit
+		return nil, 0, newRateLimitError(resp)
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -343,10 +342,9 @@ func (gh *GitHub) getStargazersPage(ctx context.Context, repo Repository, page i
 			return gh.getStargazersPage(ctx, repo, page)
 		}
 		return stars, err
-	case http.StatusForbidden:
-		rateLimits.Inc()
+	case http.StatusForbidden, http.StatusTooManyRequests:
 		log.Warn("rate limit hit")
-		return stars, ErrRateLimit
+		return stars, newRateLimitError(resp)
 	case http.StatusOK:
 		if err := json.Unmarshal(bts, &stars); err != nil {
 			return stars, err
@@ -0,0 +1,94 @@
+// Package queue provides the queues background jobs are pushed to.
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// popTimeout is how long a redis pop blocks before checking whether its
+// context is done.
+const popTimeout = time.Second
+
+// ErrFull happens when pushing to a full in-memory queue.
+var ErrFull = errors.New("queue is full")
+
+// Queue is a FIFO of job names.
+type Queue interface {
+	// Push adds name to the end of the queue.
+	Push(ctx context.Context, name string) error
+	// Pop takes the first name from the queue, blocking until there is one
+	// or ctx is done.
+	Pop(ctx context.Context) (string, error)
+}
+
+// Redis is a queue persisted in a redis list, so queued jobs survive
+// restarts and are shared by every instance.
+type Redis struct {
+	redis *redis.Client
+	key   string
+}
+
+// NewRedis returns a queue stored in the given redis list key.
+func NewRedis(redis *redis.Client, key string) *Redis {
+	return &Redis{redis: redis, key: key}
+}
+
+// Push adds name to the end of the queue.
+func (q *Redis) Push(_ context.Context, name string) error {
+	return q.redis.LPush(q.key, name).Err()
+}
+
+// Pop takes the first name from the queue.
+func (q *Redis) Pop(ctx context.Context) (string, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+
+		result, err := q.redis.BRPop(popTimeout, q.key).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		// result is the key and the popped value.
+		return result[1], nil
+	}
+}
+
+// Memory is a queue kept in memory, used when redis isn't available. Queued
+// jobs are lost on restart.
+type Memory struct {
+	names chan string
+}
+
+// NewMemory returns an in-memory queue holding at most size names.
+func NewMemory(size int) *Memory {
+	return &Memory{names: make(chan string, size)}
+}
+
+// Push adds name to the end of the queue, failing with ErrFull when it is
+// full.
+func (q *Memory) Push(_ context.Context, name string) error {
+	select {
+	case q.names <- name:
+		return nil
+	default:
+		return ErrFull
+	}
+}
+
+// Pop takes the first name from the queue.
+func (q *Memory) Pop(ctx context.Context) (string, error) {
+	select {
+	case name := <-q.names:
+		return name, nil
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
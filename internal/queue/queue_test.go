@@ -0,0 +1,55 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/go-redis/redis"
+	"github.com/matryer/is"
+)
+
+func testQueue(t *testing.T, q Queue) {
+	t.Helper()
+	is := is.New(t)
+	ctx := context.Background()
+
+	is.NoErr(q.Push(ctx, "a/a"))
+	is.NoErr(q.Push(ctx, "b/b"))
+
+	name, err := q.Pop(ctx)
+	is.NoErr(err)
+	is.Equal("a/a", name) // should pop in push order
+
+	name, err = q.Pop(ctx)
+	is.NoErr(err)
+	is.Equal("b/b", name) // should pop in push order
+
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = q.Pop(ctx)
+	is.Equal(context.DeadlineExceeded, err) // should stop waiting when the context is done
+}
+
+func TestMemory(t *testing.T) {
+	testQueue(t, NewMemory(10))
+}
+
+func TestMemory_Full(t *testing.T) {
+	is := is.New(t)
+	q := NewMemory(1)
+	is.NoErr(q.Push(context.Background(), "a/a"))
+	is.Equal(ErrFull, q.Push(context.Background(), "b/b")) // should not block when full
+}
+
+func TestRedis(t *testing.T) {
+	mr, _ := miniredis.Run()
+	t.Cleanup(mr.Close)
+	rc := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	t.Cleanup(func() { _ = rc.Close() })
+
+	testQueue(t, NewRedis(rc, "test_queue"))
+}
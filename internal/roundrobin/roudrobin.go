@@ -11,6 +11,8 @@ import (
 // RoundRobiner can pick a token from a list of tokens.
 type RoundRobiner interface {
 	Pick() (*Token, error)
+	// Valid returns all the tokens not invalidated.
+	Valid() []*Token
 }
 
 // New round robin implementation with the given list of tokens.
@@ -48,12 +50,26 @@ func (rr *realRoundRobin) doPick(try int) (*Token, error) {
 	return rr.doPick(try + 1)
 }
 
+func (rr *realRoundRobin) Valid() []*Token {
+	var valid []*Token
+	for _, token := range rr.tokens {
+		if token.OK() {
+			valid = append(valid, token)
+		}
+	}
+	return valid
+}
+
 type noTokensRoundRobin struct{}
 
 func (rr *noTokensRoundRobin) Pick() (*Token, error) {
 	return nil, nil
 }
 
+func (rr *noTokensRoundRobin) Valid() []*Token {
+	return nil
+}
+
 // Token is a github token.
 type Token struct {
 	token string
@@ -67,6 +67,15 @@ func TestNoValidTokens(t *testing.T) {
 	is.True(err != nil)  // should err
 }
 
+func TestValidTokens(t *testing.T) {
+	is := is.New(t)
+	rr := New(tokens)
+	invalidateN(t, rr, 1)
+
+	is.Equal(3, len(rr.Valid()))              // should skip invalidated tokens
+	is.Equal(0, len(New([]string{}).Valid())) // should have no tokens
+}
+
 func invalidateN(t *testing.T, rr RoundRobiner, n int) {
 	t.Helper()
 	is := is.New(t)
@@ -0,0 +1,129 @@
+// Package scheduler periodically refreshes the crawls of the most requested
+// repositories, so their charts are always warm and fresh.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/caarlos0/starcharts/internal/crawler"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// decay is how much the request counts weigh after every refresh, so
+// repositories that stopped being requested eventually stop being refreshed.
+const decay = 0.5
+
+var refreshes = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "starcharts",
+	Subsystem: "scheduler",
+	Name:      "refreshes_total",
+	Help:      "Total number of repositories refreshed by the scheduler",
+})
+
+var skips = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "starcharts",
+	Subsystem: "scheduler",
+	Name:      "skips_total",
+	Help:      "Total number of refreshes skipped because of the rate limit usage",
+})
+
+func init() {
+	prometheus.MustRegister(refreshes, skips)
+}
+
+// Refresher refreshes the crawl of a repository.
+type Refresher interface {
+	Refresh(ctx context.Context, name string) (crawler.Status, error)
+}
+
+// Requests tracks how often repositories are requested.
+type Requests interface {
+	MostRequested(n int) ([]string, error)
+	DecayRequests(factor float64) error
+}
+
+// RateUsage returns the percentage of the github rate limit in use.
+type RateUsage func(ctx context.Context) (int, error)
+
+// Scheduler refreshes the most requested repositories.
+type Scheduler struct {
+	refresher Refresher
+	requests  Requests
+	usage     RateUsage
+
+	// Interval between refreshes.
+	Interval time.Duration
+	// Top is how many of the most requested repositories are refreshed.
+	Top int
+	// MaxRateUsage is the rate limit usage percentage above which refreshes
+	// are skipped, leaving the tokens to visitors.
+	MaxRateUsage int
+}
+
+// New returns a scheduler refreshing the 50 most requested repositories
+// every 30 minutes, while at most half the rate limit is in use.
+func New(refresher Refresher, requests Requests, usage RateUsage) *Scheduler {
+	return &Scheduler{
+		refresher:    refresher,
+		requests:     requests,
+		usage:        usage,
+		Interval:     30 * time.Minute,
+		Top:          50,
+		MaxRateUsage: 50,
+	}
+}
+
+// Start refreshes the repositories every interval, until ctx is done.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.Run(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Run refreshes the most requested repositories once, unless the rate limit
+// usage is too high, and returns how many were refreshed.
+func (s *Scheduler) Run(ctx context.Context) int {
+	usage, err := s.usage(ctx)
+	if err != nil {
+		slog.Error("failed to get rate limit usage", "error", err)
+		return 0
+	}
+	if usage > s.MaxRateUsage {
+		slog.Info("rate limit usage is too high, skipping refresh", "usage", usage, "max", s.MaxRateUsage)
+		skips.Inc()
+		return 0
+	}
+
+	names, err := s.requests.MostRequested(s.Top)
+	if err != nil {
+		slog.Error("failed to get most requested repositories", "error", err)
+		return 0
+	}
+
+	var refreshed int
+	for _, name := range names {
+		if _, err := s.refresher.Refresh(ctx, name); err != nil {
+			slog.Error("failed to refresh", "repo", name, "error", err)
+			continue
+		}
+		refreshed++
+	}
+	refreshes.Add(float64(refreshed))
+	slog.Info("refreshed most requested repositories", "count", refreshed, "usage", usage)
+
+	if err := s.requests.DecayRequests(decay); err != nil {
+		slog.Error("failed to decay request counts", "error", err)
+	}
+	return refreshed
+}
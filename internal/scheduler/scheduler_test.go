@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/caarlos0/starcharts/internal/crawler"
+	"github.com/go-redis/redis"
+	"github.com/matryer/is"
+)
+
+type fakeRefresher struct {
+	names []string
+}
+
+func (f *fakeRefresher) Refresh(_ context.Context, name string) (crawler.Status, error) {
+	f.names = append(f.names, name)
+	return crawler.Status{State: crawler.StateQueued}, nil
+}
+
+func TestRun(t *testing.T) {
+	mr, _ := miniredis.Run()
+	t.Cleanup(mr.Close)
+	rc := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	cache := cache.New(rc)
+	t.Cleanup(func() { _ = cache.Close() })
+
+	for name, requests := range map[string]int{"a/a": 1, "b/b": 8, "c/c": 4} {
+		for range requests {
+			is.New(t).NoErr(cache.CountRequest(name))
+		}
+	}
+
+	var usage int
+	refresher := &fakeRefresher{}
+	scheduler := New(refresher, cache, func(context.Context) (int, error) {
+		return usage, nil
+	})
+	scheduler.Top = 2
+
+	t.Run("refresh most requested", func(t *testing.T) {
+		is := is.New(t)
+		is.Equal(2, scheduler.Run(context.Background()))
+		is.Equal([]string{"b/b", "c/c"}, refresher.names) // should refresh the most requested first
+
+		names, err := cache.MostRequested(10)
+		is.NoErr(err)
+		is.Equal([]string{"b/b", "c/c"}, names) // should forget the repositories no longer requested
+	})
+
+	t.Run("skip at peak usage", func(t *testing.T) {
+		is := is.New(t)
+		refresher.names = nil
+		usage = 80
+		is.Equal(0, scheduler.Run(context.Background()))
+		is.Equal(0, len(refresher.names)) // should not refresh
+	})
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"log/slog"
 	"net/http"
@@ -11,7 +12,11 @@ import (
 	"github.com/caarlos0/starcharts/config"
 	"github.com/caarlos0/starcharts/controller"
 	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/caarlos0/starcharts/internal/chart"
+	"github.com/caarlos0/starcharts/internal/crawler"
 	"github.com/caarlos0/starcharts/internal/github"
+	"github.com/caarlos0/starcharts/internal/queue"
+	"github.com/caarlos0/starcharts/internal/scheduler"
 	"github.com/go-redis/redis"
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus"
@@ -32,30 +37,43 @@ func main() {
 		slog.Error("invalid redis_url")
 		os.Exit(1)
 	}
+	if config.ThemesFile != "" {
+		if err := chart.Themes.Load(config.ThemesFile); err != nil {
+			slog.Error("failed to load themes", "error", err)
+			os.Exit(1)
+		}
+	}
+	if config.FontFile != "" {
+		if err := chart.LoadFont(config.FontFile, config.FontFamily, config.FontEmbed); err != nil {
+			slog.Error("failed to load font", "error", err)
+			os.Exit(1)
+		}
+	}
 	redis := redis.NewClient(options)
 	cache := cache.New(redis)
 	defer cache.Close() //nolint:errcheck
 	github := github.New(config, cache)
 
-	r := mux.NewRouter()
-	r.Path("/").
-		Methods(http.MethodGet).
-		Handler(controller.Index(static, version))
-	r.Path("/").
-		Methods(http.MethodPost).
-		HandlerFunc(controller.HandleForm())
-	r.PathPrefix("/static/").
-		Methods(http.MethodGet).
-		Handler(http.FileServer(http.FS(static)))
-	r.Path("/{owner}/{repo}.svg").
-		Methods(http.MethodGet).
-		MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
-			return !strings.Contains(r.Header.Get("Accept"), "text/html")
-		}).
-		Handler(controller.GetRepoChart(github, cache))
-	r.Path("/{owner}/{repo}").
-		Methods(http.MethodGet).
-		Handler(controller.GetRepo(static, github, cache, version))
+	if len(os.Args) > 1 && os.Args[1] == "warm" {
+		if err := warm(os.Args[2:], github, cache); err != nil {
+			slog.Error("failed to warm cache", "error", err)
+			_ = cache.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	pool := crawler.NewPool(crawler.GitHub(github), cache, newQueue(config.Queue, redis), config.CrawlWorkers)
+	pool.Start(context.Background())
+	if config.RefreshInterval > 0 {
+		scheduler := scheduler.New(pool, cache, github.RateUsage)
+		scheduler.Interval = config.RefreshInterval
+		scheduler.Top = config.RefreshTop
+		scheduler.MaxRateUsage = config.RefreshMaxRateUsage
+		scheduler.Start(context.Background())
+	}
+
+	r := newRouter(github, cache, pool, true)
 
 	// generic metrics
 	requestCounter := promauto.NewCounterVec(prometheus.CounterOpts{
@@ -88,3 +106,54 @@ func main() {
 	ctx.Info("starting up...")
 	ctx.Error("failed to start up server", "error", srv.ListenAndServe())
 }
+
+// newRouter returns the router of the charts and pages. When count is set,
+// chart requests are counted for the scheduler.
+func newRouter(github *github.GitHub, cache *cache.Redis, pool *crawler.Pool, count bool) *mux.Router {
+	charts := func(h http.Handler) http.Handler {
+		if count {
+			return controller.CountRequests(cache, h)
+		}
+		return h
+	}
+
+	r := mux.NewRouter()
+	r.Path("/").
+		Methods(http.MethodGet).
+		Handler(controller.Index(static, version))
+	r.Path("/").
+		Methods(http.MethodPost).
+		HandlerFunc(controller.HandleForm())
+	r.PathPrefix("/static/").
+		Methods(http.MethodGet).
+		Handler(http.FileServer(http.FS(static)))
+	r.Path("/{owner}/{repo}.svg").
+		Methods(http.MethodGet).
+		MatcherFunc(func(r *http.Request, rm *mux.RouteMatch) bool {
+			return !strings.Contains(r.Header.Get("Accept"), "text/html")
+		}).
+		Handler(charts(controller.GetRepoChart(pool, cache)))
+	r.Path("/{owner}/{repo}/sparkline.svg").
+		Methods(http.MethodGet).
+		Handler(charts(controller.GetRepoSparkline(pool, cache)))
+	r.Path("/{owner}/{repo}/status.json").
+		Methods(http.MethodGet).
+		Handler(controller.GetRepoStatus(pool))
+	r.Path("/{owner}/{repo}").
+		Methods(http.MethodGet).
+		Handler(controller.GetRepo(static, github, cache, version))
+	return r
+}
+
+// newQueue returns the crawl queue, falling back to memory when redis is
+// unavailable.
+func newQueue(kind string, rc *redis.Client) queue.Queue {
+	if kind != "redis" {
+		return queue.NewMemory(1024)
+	}
+	if err := rc.Ping().Err(); err != nil {
+		slog.Warn("redis unavailable, queueing crawls in memory", "error", err)
+		return queue.NewMemory(1024)
+	}
+	return queue.NewRedis(rc, "crawl_queue")
+}
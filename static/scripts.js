@@ -65,7 +65,7 @@
                 return url.searchParams.set(color.name, color.value);
             });
         } else {
-            url.searchParams.set('variant', variant);
+            url.searchParams.set('theme', variant);
         }
 
         chartElement.src = url.toString();
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/signal"
+	"strings"
+	"sync/atomic"
+
+	"github.com/caarlos0/starcharts/controller"
+	"github.com/caarlos0/starcharts/internal/cache"
+	"github.com/caarlos0/starcharts/internal/chart"
+	"github.com/caarlos0/starcharts/internal/crawler"
+	"github.com/caarlos0/starcharts/internal/github"
+	"github.com/caarlos0/starcharts/internal/queue"
+	"golang.org/x/sync/errgroup"
+)
+
+// warm crawls the repositories listed in a file, or in stdin, and renders
+// their charts, so their first visitors don't wait for a crawl.
+func warm(args []string, github *github.GitHub, cache *cache.Redis) error {
+	flags := flag.NewFlagSet("warm", flag.ContinueOnError)
+	concurrency := flags.Int("concurrency", 4, "how many repositories are warmed at once")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: starcharts warm [-concurrency n] [file]")
+		fmt.Fprintln(flags.Output(), "\nReads one owner/repo per line from file, or stdin when omitted or -.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	input := io.Reader(os.Stdin)
+	if name := flags.Arg(0); name != "" && name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close() //nolint:errcheck
+		input = f
+	}
+
+	names, err := readRepos(input)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	pool := crawler.NewPool(crawler.GitHub(github), cache, queue.NewMemory(len(names)), *concurrency)
+	pool.Start(ctx)
+	// warmed charts aren't counted as requests, to not skew the refreshes.
+	handler := newRouter(github, cache, pool, false)
+
+	var (
+		wg     errgroup.Group
+		failed atomic.Int32
+	)
+	wg.SetLimit(max(*concurrency, 1))
+	for _, name := range names {
+		wg.Go(func() error {
+			log := slog.With("repo", name)
+			if err := warmRepo(ctx, pool, handler, name); err != nil {
+				log.Error("failed to warm", "error", err)
+				failed.Add(1)
+				return nil
+			}
+			log.Info("warmed")
+			return nil
+		})
+	}
+	_ = wg.Wait()
+
+	if n := failed.Load(); n > 0 {
+		return fmt.Errorf("failed to warm %d of %d repositories", n, len(names))
+	}
+	return ctx.Err()
+}
+
+// readRepos reads one repository per line, either as owner/repo or as its
+// github URL, skipping blank lines and # comments.
+func readRepos(r io.Reader) ([]string, error) {
+	var names []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, err := controller.ParseRepo(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		names = append(names, name)
+	}
+	return names, scanner.Err()
+}
+
+// warmRepo crawls the repository, caching its details and stargazer pages,
+// then renders its chart in every theme and its sparkline through the
+// handler, which caches them as if they were requested.
+func warmRepo(ctx context.Context, pool *crawler.Pool, handler http.Handler, name string) error {
+	if _, err := pool.Refresh(ctx, name); err != nil {
+		return err
+	}
+	status, err := pool.Wait(ctx, name, crawler.Timeout)
+	if err != nil {
+		return err
+	}
+	switch status.State {
+	case crawler.StateDone:
+	case crawler.StateFailed:
+		return status.Err()
+	default:
+		return errors.New("crawl timed out")
+	}
+
+	var paths []string
+	for _, theme := range chart.Themes.Names() {
+		paths = append(paths, fmt.Sprintf("/%s.svg?theme=%s", name, theme))
+	}
+	paths = append(paths, fmt.Sprintf("/%s/sparkline.svg", name))
+
+	for _, path := range paths {
+		req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			return fmt.Errorf("failed to render %s: %d", path, rec.Code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestReadRepos(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		input string
+		repos []string
+		err   string
+	}{
+		{
+			name:  "owner/repo",
+			input: "caarlos0/starcharts\ngoreleaser/goreleaser\n",
+			repos: []string{"caarlos0/starcharts", "goreleaser/goreleaser"},
+		},
+		{
+			name:  "comments and blank lines",
+			input: "# charts\n\n  caarlos0/starcharts  \n",
+			repos: []string{"caarlos0/starcharts"},
+		},
+		{
+			name: "urls",
+			input: strings.Join([]string{
+				"https://github.com/caarlos0/starcharts",
+				"http://github.com/caarlos0/starcharts/",
+				"github.com/caarlos0/starcharts",
+				"https://www.github.com/caarlos0/starcharts.git",
+				"https://github.com/caarlos0/starcharts/tree/main/controller",
+			}, "\n"),
+			repos: []string{
+				"caarlos0/starcharts",
+				"caarlos0/starcharts",
+				"caarlos0/starcharts",
+				"caarlos0/starcharts",
+				"caarlos0/starcharts",
+			},
+		},
+		{
+			name:  "dots and dashes",
+			input: "go-task/task.dev\n",
+			repos: []string{"go-task/task.dev"},
+		},
+		{
+			name:  "invalid",
+			input: "caarlos0/starcharts\ncaarlos0\n",
+			err:   "line 2: invalid repository: caarlos0, must be owner/repo",
+		},
+		{
+			name:  "invalid characters",
+			input: "caarlos0/star charts\n",
+			err:   "line 1: invalid repository: caarlos0/star charts, must be owner/repo",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			is := is.New(t)
+			repos, err := readRepos(strings.NewReader(tt.input))
+			if tt.err != "" {
+				is.True(err != nil)           // should fail
+				is.Equal(tt.err, err.Error()) // should tell the invalid line
+				return
+			}
+			is.NoErr(err)
+			is.Equal(tt.repos, repos) // should parse the repositories
+		})
+	}
+}